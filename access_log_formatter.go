@@ -0,0 +1,188 @@
+package sklog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatCommon is the Common Log Format.
+	FormatCommon = `$remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent`
+	// FormatCombined is the Combined Log Format, default format of Apache and Nginx.
+	FormatCombined = FormatCommon + ` "$http_referer" "$http_user_agent"`
+
+	formatTimeLocal = "02/Jan/2006:15:04:05 -0700"
+)
+
+// accessLogVariables maps Nginx log_format variables to functions that compute them from a record.
+var accessLogVariables = map[string]func(map[string]interface{}) string{
+	"remote_addr": func(m map[string]interface{}) string {
+		addr := accessLogValue(m, KeyHTTPRemoteAddr)
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			return host
+		}
+		return addr
+	},
+	"remote_user": func(m map[string]interface{}) string {
+		return "-"
+	},
+	"time_local": func(m map[string]interface{}) string {
+		ts := accessLogValue(m, KeyTimestamp)
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			return t.Format(formatTimeLocal)
+		}
+		return ts
+	},
+	"request": func(m map[string]interface{}) string {
+		return accessLogValue(m, KeyHTTPMethod) + " " + accessLogRequestURI(m) + " " + accessLogValue(m, KeyHTTPProto)
+	},
+	"request_method": func(m map[string]interface{}) string {
+		return accessLogValue(m, KeyHTTPMethod)
+	},
+	"request_uri": accessLogRequestURI,
+	"server_protocol": func(m map[string]interface{}) string {
+		return accessLogValue(m, KeyHTTPProto)
+	},
+	"status": func(m map[string]interface{}) string {
+		return accessLogValue(m, KeyHTTPStatus)
+	},
+	"body_bytes_sent": func(m map[string]interface{}) string {
+		return accessLogValue(m, KeyHTTPBytes)
+	},
+	"request_time": func(m map[string]interface{}) string {
+		if d, ok := m[KeyHTTPDuration].(time.Duration); ok {
+			return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+		}
+		return accessLogValue(m, KeyHTTPDuration)
+	},
+	"http_referer": func(m map[string]interface{}) string {
+		return accessLogValue(m, KeyHTTPReferer)
+	},
+	"http_user_agent": func(m map[string]interface{}) string {
+		return accessLogValue(m, KeyHTTPUserAgent)
+	},
+	"request_id": func(m map[string]interface{}) string {
+		return accessLogValue(m, KeyRequestID)
+	},
+}
+
+type accessLogFormatter struct {
+	// parts alternates between literals (even indexes) and variable names (odd indexes).
+	parts []string
+}
+
+// NewAccessLogFormatter allocates Formatter that renders records produced by NewAccessLogHandler
+// as access log lines, so they can be consumed by tools that expect Apache or Nginx logs.
+// Format uses Nginx log_format syntax ($name or ${name}), see FormatCommon and FormatCombined.
+// Supported variables are remote_addr, remote_user, time_local, request, request_method, request_uri,
+// server_protocol, status, body_bytes_sent, request_time, http_referer, http_user_agent and request_id,
+// any other name is looked up in the record as is. Missing values are rendered as "-".
+// Like in Nginx, double quotes, backslashes and bytes outside of printable ASCII are escaped as \xHH,
+// so that clients cannot break the line format using, for example, User-Agent header.
+func NewAccessLogFormatter(format string) Formatter {
+	return &accessLogFormatter{parts: parseAccessLogFormat(format)}
+}
+
+// Format implements Formatter interface.
+func (alf *accessLogFormatter) Format(w io.Writer, v interface{}) (int, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("sklog: access log formatter expects map[string]interface{} got %T", v)
+	}
+
+	b := bytes.NewBuffer(nil)
+	for i, part := range alf.parts {
+		if i%2 == 0 {
+			b.WriteString(part)
+			continue
+		}
+		if fn, ok := accessLogVariables[part]; ok {
+			writeAccessLogEscaped(b, fn(m))
+		} else {
+			writeAccessLogEscaped(b, accessLogValue(m, part))
+		}
+	}
+
+	return w.Write(b.Bytes())
+}
+
+func parseAccessLogFormat(format string) []string {
+	var (
+		parts   []string
+		literal []byte
+	)
+	for i := 0; i < len(format); i++ {
+		if format[i] != '$' {
+			literal = append(literal, format[i])
+			continue
+		}
+
+		start, end := i+1, i+1
+		if end < len(format) && format[end] == '{' {
+			if j := strings.IndexByte(format[end:], '}'); j > 0 {
+				parts = append(parts, string(literal), format[end+1:end+j])
+				literal = literal[:0]
+				i = end + j
+				continue
+			}
+		}
+		for end < len(format) && isVariableByte(format[end]) {
+			end++
+		}
+		if end == start {
+			literal = append(literal, '$')
+			continue
+		}
+
+		parts = append(parts, string(literal), format[start:end])
+		literal = literal[:0]
+		i = end - 1
+	}
+
+	return append(parts, string(literal))
+}
+
+func isVariableByte(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+func accessLogValue(m map[string]interface{}, key string) string {
+	v, ok := m[key]
+	if !ok || v == nil {
+		return "-"
+	}
+	if s := fmt.Sprint(v); s != "" {
+		return s
+	}
+
+	return "-"
+}
+
+// accessLogRequestURI returns request URI together with query string, path is used if record does not have it.
+func accessLogRequestURI(m map[string]interface{}) string {
+	if _, ok := m[KeyHTTPRequestURI]; ok {
+		return accessLogValue(m, KeyHTTPRequestURI)
+	}
+
+	return accessLogValue(m, KeyHTTPPath)
+}
+
+func writeAccessLogEscaped(b *bytes.Buffer, s string) {
+	const hex = "0123456789ABCDEF"
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '"' || c == '\\' || c < 0x20 || c > 0x7e {
+			b.WriteString(`\x`)
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0xf])
+			continue
+		}
+		b.WriteByte(c)
+	}
+}
@@ -0,0 +1,88 @@
+package sklog_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccessLogFormatter(t *testing.T) {
+	record := map[string]interface{}{
+		sklog.KeyTimestamp:      "2017-03-04T12:30:45Z",
+		sklog.KeyHTTPMethod:     "GET",
+		sklog.KeyHTTPPath:       "/users",
+		sklog.KeyHTTPProto:      "HTTP/1.1",
+		sklog.KeyHTTPStatus:     200,
+		sklog.KeyHTTPBytes:      512,
+		sklog.KeyHTTPDuration:   1500 * time.Millisecond,
+		sklog.KeyHTTPRemoteAddr: "192.0.2.1:1234",
+		sklog.KeyHTTPUserAgent:  "curl/7.52.1",
+		sklog.KeyHTTPReferer:    "",
+		sklog.KeyRequestID:      "abc",
+		"tenant":                "acme",
+	}
+
+	cases := map[string]struct {
+		format   string
+		expected string
+	}{
+		"common": {
+			format:   sklog.FormatCommon,
+			expected: `192.0.2.1 - - [04/Mar/2017:12:30:45 +0000] "GET /users HTTP/1.1" 200 512`,
+		},
+		"combined": {
+			format:   sklog.FormatCombined,
+			expected: `192.0.2.1 - - [04/Mar/2017:12:30:45 +0000] "GET /users HTTP/1.1" 200 512 "-" "curl/7.52.1"`,
+		},
+		"custom": {
+			format:   `$request_method ${request_uri}?x $status $request_time $request_id $tenant $missing $`,
+			expected: `GET /users?x 200 1.500 abc acme - $`,
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			_, err := sklog.NewAccessLogFormatter(c.format).Format(b, record)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, c.expected, b.String())
+			}
+		})
+	}
+}
+
+func TestNewAccessLogFormatter_handler(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	logger := sklog.NewLogger(
+		sklog.NewHumaneLogger(b, sklog.NewAccessLogFormatter(sklog.FormatCombined)),
+		sklog.WithTimestampFunc(func() string { return "2017-03-04T12:30:45Z" }),
+	)
+	h := sklog.NewAccessLogHandler(logger, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write([]byte("created"))
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/users?page=2", nil)
+	r.Header.Set("Referer", "http://example.com")
+	r.Header.Set("User-Agent", "test")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Equal(t, `192.0.2.1 - - [04/Mar/2017:12:30:45 +0000] "POST /users?page=2 HTTP/1.1" 201 7 "http://example.com" "test"`+"\n", b.String())
+}
+
+func TestNewAccessLogFormatter_escape(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	_, err := sklog.NewAccessLogFormatter(`"$http_user_agent"`).Format(b, map[string]interface{}{
+		sklog.KeyHTTPUserAgent: "evil\" 200 0\n\\é",
+	})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, `"evil\x22 200 0\x0A\x5C\xC3\xA9"`, b.String())
+	}
+}
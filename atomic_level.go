@@ -0,0 +1,187 @@
+package sklog
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrUnknownLevel is returned when level that is not one of Level* constants is given.
+var ErrUnknownLevel = errors.New("sklog: unknown level")
+
+type levelState struct {
+	level      string
+	subsystems map[string]string
+}
+
+// levelOverride is a temporary change of a level, base is the value it reverts to.
+type levelOverride struct {
+	base   string
+	baseOK bool
+	timer  *time.Timer
+}
+
+// AtomicLevel holds level (and per subsystem overrides) that can be safely changed at runtime.
+// It is meant to be used with NewAtomicLevelFilter.
+type AtomicLevel struct {
+	state atomic.Pointer[levelState]
+
+	mu        sync.Mutex
+	overrides map[string]*levelOverride
+}
+
+// NewAtomicLevel allocates new AtomicLevel set to given level.
+// Like SetLevel, it accepts only Level* constants, but being usually called during initialization, it panics otherwise.
+func NewAtomicLevel(level string) *AtomicLevel {
+	if _, ok := levels[level]; !ok {
+		panic(fmt.Errorf("%w: %s", ErrUnknownLevel, level))
+	}
+
+	al := &AtomicLevel{
+		overrides: make(map[string]*levelOverride),
+	}
+	al.state.Store(&levelState{level: level})
+
+	return al
+}
+
+// Level returns current level.
+func (al *AtomicLevel) Level() string {
+	return al.state.Load().level
+}
+
+// SubsystemLevels returns copy of per subsystem level overrides.
+func (al *AtomicLevel) SubsystemLevels() map[string]string {
+	subsystems := al.state.Load().subsystems
+	res := make(map[string]string, len(subsystems))
+	for s, l := range subsystems {
+		res[s] = l
+	}
+
+	return res
+}
+
+// SetLevel changes level.
+func (al *AtomicLevel) SetLevel(level string) error {
+	return al.SetLevelFor(level, 0)
+}
+
+// SetLevelFor changes level for given amount of time, after which the last permanent level is restored.
+// Zero ttl means that change is permanent. Each change replaces the temporary one that is still in effect,
+// so after SetLevelFor(LevelDebug, 10*time.Minute) and SetLevelFor(LevelWarning, 5*time.Minute)
+// the level is reverted after 5 minutes.
+func (al *AtomicLevel) SetLevelFor(level string, ttl time.Duration) error {
+	if _, ok := levels[level]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownLevel, level)
+	}
+
+	al.set("", level, true, ttl)
+	return nil
+}
+
+// SetSubsystemLevel overrides level for given subsystem.
+func (al *AtomicLevel) SetSubsystemLevel(subsystem, level string) error {
+	return al.SetSubsystemLevelFor(subsystem, level, 0)
+}
+
+// SetSubsystemLevelFor overrides level for given subsystem for given amount of time.
+// Zero ttl means that change is permanent. Empty subsystem changes the global level.
+func (al *AtomicLevel) SetSubsystemLevelFor(subsystem, level string, ttl time.Duration) error {
+	if subsystem == "" {
+		return al.SetLevelFor(level, ttl)
+	}
+	if _, ok := levels[level]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownLevel, level)
+	}
+
+	al.set(subsystem, level, true, ttl)
+	return nil
+}
+
+// ResetSubsystemLevel removes level override for given subsystem.
+func (al *AtomicLevel) ResetSubsystemLevel(subsystem string) {
+	if subsystem == "" {
+		return
+	}
+	al.set(subsystem, "", false, 0)
+}
+
+// min returns level that applies to given subsystem.
+func (al *AtomicLevel) min(subsystem string) string {
+	state := al.state.Load()
+	if level, ok := state.subsystems[subsystem]; ok {
+		return level
+	}
+
+	return state.level
+}
+
+// set changes level stored under given key, empty key means global level.
+// If ttl is greater than zero, the last permanent value is restored after that time,
+// unless it was changed again in the meantime.
+func (al *AtomicLevel) set(key, level string, ok bool, ttl time.Duration) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	prev := al.overrides[key]
+	if prev != nil {
+		prev.timer.Stop()
+		delete(al.overrides, key)
+	}
+
+	baseLevel, baseOK := al.swap(key, level, ok)
+	if ttl <= 0 {
+		return
+	}
+	if prev != nil {
+		baseLevel, baseOK = prev.base, prev.baseOK
+	}
+
+	o := &levelOverride{base: baseLevel, baseOK: baseOK}
+	o.timer = time.AfterFunc(ttl, func() {
+		al.mu.Lock()
+		defer al.mu.Unlock()
+
+		// Timer could fire while the override was being replaced.
+		if al.overrides[key] != o {
+			return
+		}
+		delete(al.overrides, key)
+		al.swap(key, o.base, o.baseOK)
+	})
+	al.overrides[key] = o
+}
+
+// swap stores new state and returns previous value for given key. It has to be called under lock.
+func (al *AtomicLevel) swap(key, level string, ok bool) (string, bool) {
+	old := al.state.Load()
+	next := &levelState{
+		level:      old.level,
+		subsystems: make(map[string]string, len(old.subsystems)+1),
+	}
+	for s, l := range old.subsystems {
+		next.subsystems[s] = l
+	}
+
+	var (
+		prevLevel string
+		prevOK    bool
+	)
+	if key == "" {
+		prevLevel, prevOK = old.level, true
+		next.level = level
+	} else {
+		prevLevel, prevOK = old.subsystems[key]
+		if ok {
+			next.subsystems[key] = level
+		} else {
+			delete(next.subsystems, key)
+		}
+	}
+
+	al.state.Store(next)
+
+	return prevLevel, prevOK
+}
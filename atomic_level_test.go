@@ -0,0 +1,89 @@
+package sklog_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtomicLevel_SetLevel(t *testing.T) {
+	al := sklog.NewAtomicLevel(sklog.LevelInfo)
+
+	assert.NoError(t, al.SetLevel(sklog.LevelDebug))
+	assert.Equal(t, sklog.LevelDebug, al.Level())
+
+	err := al.SetLevel("verbose")
+	assert.True(t, errors.Is(err, sklog.ErrUnknownLevel))
+	assert.Equal(t, sklog.LevelDebug, al.Level())
+}
+
+func TestAtomicLevel_SetLevelFor(t *testing.T) {
+	al := sklog.NewAtomicLevel(sklog.LevelInfo)
+
+	assert.NoError(t, al.SetLevelFor(sklog.LevelDebug, 10*time.Millisecond))
+	assert.Equal(t, sklog.LevelDebug, al.Level())
+	assert.Eventually(t, func() bool {
+		return al.Level() == sklog.LevelInfo
+	}, time.Second, time.Millisecond)
+}
+
+func TestAtomicLevel_SetLevelFor_overridden(t *testing.T) {
+	al := sklog.NewAtomicLevel(sklog.LevelInfo)
+
+	assert.NoError(t, al.SetLevelFor(sklog.LevelDebug, 10*time.Millisecond))
+	assert.NoError(t, al.SetLevel(sklog.LevelError))
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, sklog.LevelError, al.Level())
+}
+
+func TestAtomicLevel_SetLevelFor_replaced(t *testing.T) {
+	al := sklog.NewAtomicLevel(sklog.LevelInfo)
+
+	assert.NoError(t, al.SetLevelFor(sklog.LevelDebug, 30*time.Millisecond))
+	assert.NoError(t, al.SetLevelFor(sklog.LevelWarning, 10*time.Millisecond))
+	assert.Eventually(t, func() bool {
+		return al.Level() == sklog.LevelInfo
+	}, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, sklog.LevelInfo, al.Level())
+}
+
+func TestAtomicLevel_SetSubsystemLevelFor(t *testing.T) {
+	al := sklog.NewAtomicLevel(sklog.LevelInfo)
+
+	assert.NoError(t, al.SetSubsystemLevelFor("billing", sklog.LevelDebug, 10*time.Millisecond))
+	assert.Equal(t, map[string]string{"billing": sklog.LevelDebug}, al.SubsystemLevels())
+	assert.Eventually(t, func() bool {
+		return len(al.SubsystemLevels()) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestAtomicLevelFilter_Log(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	al := sklog.NewAtomicLevel(sklog.LevelInfo)
+	l := sklog.NewAtomicLevelFilter(log.NewJSONLogger(b), al)
+
+	sklog.Debug(l, "debug message")
+	assert.Empty(t, b.String())
+
+	assert.NoError(t, al.SetLevel(sklog.LevelDebug))
+	sklog.Debug(l, "debug message")
+	assert.Contains(t, b.String(), "debug message")
+	b.Reset()
+
+	assert.NoError(t, al.SetSubsystemLevel("grpc", sklog.LevelError))
+	sklog.Warning(l, "grpc warning", sklog.KeySubsystem, "grpc")
+	assert.Empty(t, b.String())
+	sklog.Warning(l, "billing warning", sklog.KeySubsystem, "billing")
+	assert.Contains(t, b.String(), "billing warning")
+	b.Reset()
+
+	al.ResetSubsystemLevel("grpc")
+	sklog.Warning(l, "grpc warning", sklog.KeySubsystem, "grpc")
+	assert.Contains(t, b.String(), "grpc warning")
+}
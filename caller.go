@@ -0,0 +1,121 @@
+package sklog
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+)
+
+// CallerMode defines how records are annotated with call site information.
+type CallerMode int
+
+const (
+	// CallerOff disables caller annotation.
+	CallerOff CallerMode = iota
+	// CallerFile annotates records with file:line under KeyCaller.
+	CallerFile
+	// CallerFunc works like CallerFile, but additionally adds function name under KeyFunction.
+	CallerFunc
+)
+
+const maxCallerFrames = 32
+
+var (
+	pkgPath   = reflect.TypeOf(multiLogger{}).PkgPath()
+	kitPrefix = reflect.TypeOf(log.Context{}).PkgPath() + "."
+
+	// Caller is a Valuer that returns file:line of the first call site outside of sklog and go-kit/log packages.
+	// Unlike log.DefaultCaller it does not depend on the number of frames added by sklog shorthands.
+	Caller log.Valuer = func() interface{} {
+		file, _ := caller(0)
+		return file
+	}
+	// CallerFunction is a Valuer that returns name of the function that Caller points at.
+	CallerFunction log.Valuer = func() interface{} {
+		_, function := caller(0)
+		return function
+	}
+)
+
+// SetCallerMode sets whether and how shorthands annotate records with call site information.
+func SetCallerMode(mode CallerMode) {
+	setStd(WithCallerMode(mode))
+}
+
+func appendCaller(keyvals []interface{}, mode CallerMode, skip int) []interface{} {
+	if mode == CallerOff {
+		return keyvals
+	}
+
+	file, function := caller(skip)
+	if mode == CallerFunc {
+		return append(keyvals, KeyCaller, file, KeyFunction, function)
+	}
+	return append(keyvals, KeyCaller, file)
+}
+
+// caller returns file:line and function name of the first frame that does not belong to sklog nor go-kit/log.
+// Additional skip frames are skipped after that.
+func caller(skip int) (string, string) {
+	frame, ok := callerFrame(skip)
+	if !ok {
+		return "", ""
+	}
+
+	return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line), shortFunction(frame.Function)
+}
+
+func callerFrame(skip int) (runtime.Frame, bool) {
+	pcs := make([]uintptr, maxCallerFrames)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !isInternalFrame(frame.Function) {
+			if skip == 0 {
+				return frame, true
+			}
+			skip--
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+func isInternalFrame(function string) bool {
+	return isSklogFrame(function) || strings.HasPrefix(function, kitPrefix)
+}
+
+// isSklogFrame reports whether function belongs to sklog or one of its subpackages (like ctxgrpc).
+// External test packages are not considered part of sklog.
+func isSklogFrame(function string) bool {
+	if !strings.HasPrefix(function, pkgPath) {
+		return false
+	}
+	rest := function[len(pkgPath):]
+	switch {
+	case strings.HasPrefix(rest, "."):
+		return true
+	case strings.HasPrefix(rest, "/"):
+		if i := strings.Index(rest, "."); i >= 0 {
+			rest = rest[:i]
+		}
+		return !strings.HasSuffix(rest, "_test")
+	default:
+		return false
+	}
+}
+
+// shortFunction strips import path from fully qualified function name.
+func shortFunction(function string) string {
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		return function[i+1:]
+	}
+	return function
+}
@@ -0,0 +1,73 @@
+package sklog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+// previousLine returns file:line of the line preceding the call.
+func previousLine() string {
+	_, file, l, _ := runtime.Caller(1)
+	return fmt.Sprintf("%s:%d", filepath.Base(file), l-1)
+}
+
+func decode(t *testing.T, b *bytes.Buffer) map[string]interface{} {
+	var m map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &m); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	b.Reset()
+	return m
+}
+
+func TestSetCallerMode(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := log.NewJSONLogger(b)
+
+	sklog.SetCallerMode(sklog.CallerFunc)
+	defer sklog.SetCallerMode(sklog.CallerOff)
+
+	sklog.Info(l, "info")
+	exp := previousLine()
+	got := decode(t, b)
+	assert.Equal(t, exp, got[sklog.KeyCaller])
+	assert.Equal(t, "sklog_test.TestSetCallerMode", got[sklog.KeyFunction])
+
+	sklog.Debug(sklog.NewMultiLogger(l), "debug")
+	exp = previousLine()
+	assert.Equal(t, exp, decode(t, b)[sklog.KeyCaller])
+
+	sklog.NewGRPCLogger(l).Printf("printf %s", "grpc")
+	exp = previousLine()
+	assert.Equal(t, exp, decode(t, b)[sklog.KeyCaller])
+
+	sklog.SetCallerMode(sklog.CallerOff)
+	sklog.Info(l, "info")
+	assert.NotContains(t, decode(t, b), sklog.KeyCaller)
+}
+
+func TestCaller(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := log.NewContext(log.NewJSONLogger(b)).With(
+		sklog.KeyCaller, sklog.Caller,
+		sklog.KeyFunction, sklog.CallerFunction,
+	)
+
+	sklog.Info(l, "info")
+	exp := previousLine()
+	got := decode(t, b)
+	assert.Equal(t, exp, got[sklog.KeyCaller])
+	assert.Equal(t, "sklog_test.TestCaller", got[sklog.KeyFunction])
+
+	l.Log(sklog.KeyMessage, "direct")
+	exp = previousLine()
+	assert.Equal(t, exp, decode(t, b)[sklog.KeyCaller])
+}
@@ -2,10 +2,6 @@ package sklog
 
 import "github.com/go-kit/kit/log"
 
-var (
-	contextErrorFunc = NewContextErrorGeneric
-)
-
 // Contexter is simple wrapper for Context method.
 type Contexter interface {
 	Context() []interface{}
@@ -13,10 +9,72 @@ type Contexter interface {
 
 // SetContextErrorFunc sets function that handle unknown type of error by default.
 func SetContextErrorFunc(fn func(log.Logger, error) *log.Context) {
-	contextErrorFunc = fn
+	setStd(WithContextErrorFunc(fn))
 }
 
 // NewContextErrorGeneric allocates context for generic error interface.
 func NewContextErrorGeneric(logger log.Logger, err error) *log.Context {
-	return log.NewContext(logger).With(KeyMessage, err.Error())
+	return WithErrorChain(log.NewContext(logger).With(KeyMessage, err.Error()), err)
+}
+
+// WithErrorChain adds messages of given error and all errors it wraps under KeyErrorChain.
+// Context is returned unchanged if error does not wrap anything.
+func WithErrorChain(ctx *log.Context, err error) *log.Context {
+	if chain := ErrorChain(err); len(chain) > 1 {
+		return ctx.With(KeyErrorChain, chain)
+	}
+
+	return ctx
+}
+
+// ErrorChain returns messages of given error and all errors it wraps, including those joined by errors.Join.
+func ErrorChain(err error) []string {
+	tree := errorTree(err)
+	chain := make([]string, 0, len(tree))
+	for _, e := range tree {
+		chain = append(chain, safeErrorString(e))
+	}
+
+	return chain
+}
+
+func safeErrorString(err error) string {
+	s, _ := safeError(err).(string)
+	return s
+}
+
+// contextErrorKeyvals returns key/value pairs that given context error function produces for given error.
+func contextErrorKeyvals(fn func(log.Logger, error) *log.Context, err error) (keyvals []interface{}) {
+	fn(log.LoggerFunc(func(kv ...interface{}) error {
+		keyvals = kv
+		return nil
+	}), err).Log()
+
+	return
+}
+
+// contextErrorRecord returns key/value pairs that given context error function produces for given error,
+// reduced so that reserved keys do not collide with those added by the shorthands.
+// Message is dropped, because the shorthand sets it already, other reserved keys (like error_chain,
+// which nested context functions may add more than once) keep only their last occurrence.
+func contextErrorRecord(fn func(log.Logger, error) *log.Context, err error) []interface{} {
+	ctx := contextErrorKeyvals(fn, err)
+	last := make(map[string]int, 2)
+	for i := 0; i+1 < len(ctx); i += 2 {
+		if key, ok := ctx[i].(string); ok {
+			last[key] = i
+		}
+	}
+
+	res := make([]interface{}, 0, len(ctx))
+	for i := 0; i+1 < len(ctx); i += 2 {
+		if key, ok := ctx[i].(string); ok {
+			if _, reserved := reservedKeys[key]; reserved && (key == KeyMessage || last[key] != i) {
+				continue
+			}
+		}
+		res = append(res, ctx[i], ctx[i+1])
+	}
+
+	return res
 }
@@ -0,0 +1,105 @@
+package sklog
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/go-kit/kit/log"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+type contextErrorHandler func(log.Logger, error, error) (*log.Context, bool)
+
+// ContextErrorRegistry dispatches errors to context error handlers registered for their types.
+// The error chain is walked level by level, starting with the outermost error.
+// At each level handlers are consulted in registration order, first one whose type matches
+// an error at that level wins. Because of that, handler registered for a wrapping error
+// (e.g. *url.Error) takes precedence over handler registered for an error it wraps,
+// regardless of the order they were registered in.
+// If none matches, fallback function is used, which is NewContextErrorGeneric by default.
+type ContextErrorRegistry struct {
+	mu       sync.RWMutex
+	handlers []contextErrorHandler
+	fallback func(log.Logger, error) *log.Context
+}
+
+// NewContextErrorRegistry allocates empty registry.
+func NewContextErrorRegistry() *ContextErrorRegistry {
+	return &ContextErrorRegistry{
+		fallback: NewContextErrorGeneric,
+	}
+}
+
+// RegisterContextError registers handler for errors of type T.
+// T is usually pointer to an error type, but it can be an interface as well.
+// It panics if T is neither an interface nor a type that implements error.
+func RegisterContextError[T any](r *ContextErrorRegistry, fn func(log.Logger, T) *log.Context) {
+	if typ := reflect.TypeOf((*T)(nil)).Elem(); typ.Kind() != reflect.Interface && !typ.Implements(errorType) {
+		panic(fmt.Sprintf("sklog: context error handler type %s is neither an interface nor implements error", typ))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers = append(r.handlers, func(logger log.Logger, err, node error) (*log.Context, bool) {
+		target, ok := node.(T)
+		if !ok {
+			as, ok := node.(interface{ As(interface{}) bool })
+			if !ok || !as.As(&target) {
+				return nil, false
+			}
+		}
+		return WithErrorChain(fn(logger, target), err), true
+	})
+}
+
+// SetFallback sets function that is used if no handler matches given error.
+func (r *ContextErrorRegistry) SetFallback(fn func(log.Logger, error) *log.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fallback = fn
+}
+
+// NewContextError creates context for given error using handler registered for its type.
+// It can be passed to SetContextErrorFunc.
+func (r *ContextErrorRegistry) NewContextError(logger log.Logger, err error) *log.Context {
+	r.mu.RLock()
+	handlers, fallback := r.handlers, r.fallback
+	r.mu.RUnlock()
+
+	for level := []error{err}; len(level) > 0; level = unwrapLevel(level) {
+		for _, handler := range handlers {
+			for _, node := range level {
+				if ctx, ok := handler(logger, err, node); ok {
+					return ctx
+				}
+			}
+		}
+	}
+
+	return fallback(logger, err)
+}
+
+// unwrapLevel returns errors directly wrapped by given ones.
+func unwrapLevel(errs []error) []error {
+	var next []error
+	for _, err := range errs {
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			if e := x.Unwrap(); e != nil {
+				next = append(next, e)
+			}
+		case interface{ Unwrap() []error }:
+			for _, e := range x.Unwrap() {
+				if e != nil {
+					next = append(next, e)
+				}
+			}
+		}
+	}
+
+	return next
+}
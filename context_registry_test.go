@@ -0,0 +1,100 @@
+package sklog_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+type firstError struct{}
+
+func (fe *firstError) Error() string { return "sklog_test: first error" }
+
+type secondError struct{}
+
+func (se *secondError) Error() string { return "sklog_test: second error" }
+
+type outerError struct{ err error }
+
+func (oe *outerError) Error() string { return "sklog_test: outer error: " + oe.err.Error() }
+func (oe *outerError) Unwrap() error { return oe.err }
+
+func TestContextErrorRegistry_NewContextError(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := log.NewJSONLogger(b)
+
+	r := sklog.NewContextErrorRegistry()
+	sklog.RegisterContextError(r, func(logger log.Logger, err *firstError) *log.Context {
+		return sklog.NewContextErrorGeneric(logger, err).With("handler", "first")
+	})
+	sklog.RegisterContextError(r, func(logger log.Logger, err *secondError) *log.Context {
+		return sklog.NewContextErrorGeneric(logger, err).With("handler", "second")
+	})
+	sklog.RegisterContextError(r, func(logger log.Logger, err *outerError) *log.Context {
+		return sklog.NewContextErrorGeneric(logger, err).With("handler", "outer")
+	})
+
+	success := map[string]struct {
+		err      error
+		expected string
+	}{
+		"first": {
+			err:      &firstError{},
+			expected: `"handler":"first"`,
+		},
+		"second-wrapped": {
+			err:      fmt.Errorf("wrapped: %w", &secondError{}),
+			expected: `"handler":"second"`,
+		},
+		"registration-order": {
+			err:      errors.Join(&secondError{}, &firstError{}),
+			expected: `"handler":"first"`,
+		},
+		"outermost-first": {
+			err:      &outerError{err: &firstError{}},
+			expected: `"handler":"outer"`,
+		},
+		"outermost-first-wrapped": {
+			err:      fmt.Errorf("wrapped: %w", errors.Join(&secondError{}, &outerError{err: &firstError{}})),
+			expected: `"handler":"second"`,
+		},
+		"fallback": {
+			err:      errors.New("sklog_test: unknown error"),
+			expected: `"msg":"sklog_test: unknown error"`,
+		},
+	}
+
+	for name, data := range success {
+		if assert.NoError(t, r.NewContextError(l, data.err).Log(), name) {
+			assert.Contains(t, b.String(), data.expected, name)
+		}
+		b.Reset()
+	}
+
+	r.SetFallback(func(logger log.Logger, err error) *log.Context {
+		return log.NewContext(logger).With("handler", "fallback")
+	})
+	r.NewContextError(l, errors.New("sklog_test: unknown error")).Log()
+	assert.Contains(t, b.String(), `"handler":"fallback"`)
+	b.Reset()
+
+	sklog.SetContextErrorFunc(r.NewContextError)
+	defer sklog.SetContextErrorFunc(sklog.NewContextErrorGeneric)
+
+	sklog.Error(l, fmt.Errorf("wrapped: %w", &firstError{}))
+	assert.Contains(t, b.String(), `"handler":"first"`)
+	assert.Contains(t, b.String(), `"error_chain":["wrapped: sklog_test: first error","sklog_test: first error"]`)
+}
+
+func TestRegisterContextError_invalidType(t *testing.T) {
+	assert.Panics(t, func() {
+		sklog.RegisterContextError(sklog.NewContextErrorRegistry(), func(logger log.Logger, s string) *log.Context {
+			return log.NewContext(logger)
+		})
+	})
+}
@@ -0,0 +1,37 @@
+package sklog_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorChain(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+
+	assert.Equal(t, []string{"first"}, sklog.ErrorChain(first))
+	assert.Equal(t, []string{"wrapped: first", "first"}, sklog.ErrorChain(fmt.Errorf("wrapped: %w", first)))
+	assert.Equal(t,
+		[]string{"outer: first\nsecond", "first\nsecond", "first", "second"},
+		sklog.ErrorChain(fmt.Errorf("outer: %w", errors.Join(first, second))),
+	)
+	assert.Empty(t, sklog.ErrorChain(nil))
+}
+
+func TestNewContextErrorGeneric(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := log.NewJSONLogger(b)
+
+	sklog.NewContextErrorGeneric(l, errors.New("sklog_test: example error")).Log()
+	assert.NotContains(t, b.String(), sklog.KeyErrorChain)
+	b.Reset()
+
+	sklog.NewContextErrorGeneric(l, fmt.Errorf("wrapped: %w", errors.New("sklog_test: example error"))).Log()
+	assert.Contains(t, b.String(), `"error_chain":["wrapped: sklog_test: example error","sklog_test: example error"]`)
+}
@@ -0,0 +1,200 @@
+package ctxgrpc
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/piotrkowalczuk/sklog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type attemptContextKey struct{}
+
+// ContextWithAttempt returns copy of given context that carries attempt number.
+// Retry loops can use it so client interceptors log which attempt a call was. By default it is 1.
+// Retries performed by gRPC itself (retry policy in service config) are transparent to interceptors,
+// each of them is logged as the attempt stored in the context.
+func ContextWithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptContextKey{}, attempt)
+}
+
+func attemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptContextKey{}).(int); ok {
+		return attempt
+	}
+
+	return 1
+}
+
+// NewUnaryClientInterceptor returns interceptor that logs every call with its target, method, attempt number,
+// time remaining until the deadline, duration and status code. Level is derived from the status code (see Level).
+// Message is constant, status message of a failed call is logged under KeyStatusMessage,
+// and its details are decoded into grpc_* fields the same way NewContextErrorGeneric does.
+// Using WithFailuresOnly and WithSlowThreshold options the output can be limited to failed or slow calls.
+func NewUnaryClientInterceptor(logger *sklog.Logger, opts ...InterceptorOption) grpc.UnaryClientInterceptor {
+	o := newInterceptorOptions(opts)
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		start := time.Now()
+		kv := clientKeyvals(ctx, o, cc, method)
+
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+
+		elapsed := time.Since(start)
+		if !o.shouldLog(err, elapsed) {
+			return err
+		}
+		if n, ok := size(req); ok {
+			kv = append(kv, KeyRequestSize, n)
+		}
+		if n, ok := size(reply); ok && err == nil {
+			kv = append(kv, KeyResponseSize, n)
+		}
+		if o.payloads {
+			kv = append(kv, KeyRequest, payload(req))
+			if err == nil {
+				kv = append(kv, KeyResponse, payload(reply))
+			}
+		}
+		logResult(logger.With(sklog.KeyvalsFromContext(ctx)...), "call completed", err, append(kv, KeyDuration, elapsed))
+
+		return err
+	}
+}
+
+// NewStreamClientInterceptor works like NewUnaryClientInterceptor but for streams.
+// Record is logged once the stream is finished, that is when receiving a message fails (io.EOF means success),
+// the single response of a client-streaming call is received, sending, closing or reading headers fails,
+// the context is done or the stream could not be established.
+// Instead of sizes it logs number of messages received and sent.
+func NewStreamClientInterceptor(logger *sklog.Logger, opts ...InterceptorOption) grpc.StreamClientInterceptor {
+	o := newInterceptorOptions(opts)
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs := &clientStream{
+			logger:        logger.With(sklog.KeyvalsFromContext(ctx)...),
+			opts:          o,
+			start:         time.Now(),
+			kv:            clientKeyvals(ctx, o, cc, method),
+			serverStreams: desc.ServerStreams,
+			done:          make(chan struct{}),
+		}
+
+		stream, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			cs.finish(err)
+			return nil, err
+		}
+		cs.ClientStream = stream
+
+		go func() {
+			select {
+			case <-ctx.Done():
+				cs.finish(status.FromContextError(ctx.Err()).Err())
+			case <-cs.done:
+			}
+		}()
+
+		return cs, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	logger        *sklog.Logger
+	opts          *interceptorOptions
+	start         time.Time
+	kv            []interface{}
+	serverStreams bool
+	once          sync.Once
+	done          chan struct{}
+	received      atomic.Int64
+	sent          atomic.Int64
+}
+
+// Header implements grpc.ClientStream interface.
+func (cs *clientStream) Header() (metadata.MD, error) {
+	md, err := cs.ClientStream.Header()
+	if err != nil {
+		cs.finish(err)
+	}
+
+	return md, err
+}
+
+// CloseSend implements grpc.ClientStream interface.
+func (cs *clientStream) CloseSend() error {
+	err := cs.ClientStream.CloseSend()
+	if err != nil {
+		cs.finish(err)
+	}
+
+	return err
+}
+
+// SendMsg implements grpc.ClientStream interface.
+// io.EOF means that the stream was aborted, the status is then returned by RecvMsg.
+func (cs *clientStream) SendMsg(m interface{}) error {
+	err := cs.ClientStream.SendMsg(m)
+	switch err {
+	case nil:
+		cs.sent.Add(1)
+	case io.EOF:
+	default:
+		cs.finish(err)
+	}
+
+	return err
+}
+
+// RecvMsg implements grpc.ClientStream interface.
+func (cs *clientStream) RecvMsg(m interface{}) error {
+	err := cs.ClientStream.RecvMsg(m)
+	switch err {
+	case nil:
+		cs.received.Add(1)
+		if !cs.serverStreams {
+			cs.finish(nil)
+		}
+	case io.EOF:
+		cs.finish(nil)
+	default:
+		cs.finish(err)
+	}
+
+	return err
+}
+
+func (cs *clientStream) finish(err error) {
+	cs.once.Do(func() {
+		close(cs.done)
+
+		elapsed := time.Since(cs.start)
+		if !cs.opts.shouldLog(err, elapsed) {
+			return
+		}
+
+		kv := append(cs.kv, KeyDuration, elapsed, KeyMessagesReceived, cs.received.Load(), KeyMessagesSent, cs.sent.Load())
+		logResult(cs.logger, "stream completed", err, kv)
+	})
+}
+
+func clientKeyvals(ctx context.Context, o *interceptorOptions, cc *grpc.ClientConn, method string) []interface{} {
+	kv := []interface{}{KeyMethod, method, KeyAttempt, attemptFromContext(ctx)}
+	if cc != nil {
+		kv = append(kv, KeyTarget, cc.Target())
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		kv = append(kv, KeyDeadline, time.Until(deadline))
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		kv = o.appendMetadata(kv, md)
+	}
+
+	return kv
+}
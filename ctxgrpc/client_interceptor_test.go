@@ -0,0 +1,215 @@
+package ctxgrpc_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/piotrkowalczuk/sklog/ctxgrpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewUnaryClientInterceptor(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewUnaryClientInterceptor(sklog.NewLogger(log.NewJSONLogger(b)))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ctx = ctxgrpc.ContextWithAttempt(ctx, 2)
+
+	err := interceptor(ctx, "/example.Service/Method", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	})
+
+	if assert.NoError(t, err) {
+		assert.Contains(t, b.String(), `"level":"info"`)
+		assert.Contains(t, b.String(), `"msg":"call completed"`)
+		assert.Contains(t, b.String(), `"code":"OK"`)
+		assert.Contains(t, b.String(), `"grpc_method":"/example.Service/Method"`)
+		assert.Contains(t, b.String(), `"grpc_attempt":2`)
+		assert.Contains(t, b.String(), `"grpc_deadline"`)
+		assert.Contains(t, b.String(), `"grpc_duration"`)
+	}
+}
+
+func TestNewUnaryClientInterceptor_error(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewUnaryClientInterceptor(sklog.NewLogger(log.NewJSONLogger(b), sklog.WithStackTraceDepth(0)))
+	expected := status.Error(codes.Unavailable, "connection refused")
+
+	err := interceptor(context.Background(), "/example.Service/Method", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return expected
+	})
+
+	assert.Equal(t, expected, err)
+	assert.Contains(t, b.String(), `"level":"error"`)
+	assert.Contains(t, b.String(), `"msg":"call completed"`)
+	assert.Contains(t, b.String(), `"grpc_status_message":"connection refused"`)
+	assert.Contains(t, b.String(), `"code":"Unavailable"`)
+	assert.Contains(t, b.String(), `"grpc_attempt":1`)
+	assert.NotContains(t, b.String(), `"grpc_deadline"`)
+}
+
+func TestNewUnaryClientInterceptor_filter(t *testing.T) {
+	cases := map[string]struct {
+		opts   []ctxgrpc.InterceptorOption
+		err    error
+		delay  time.Duration
+		logged bool
+	}{
+		"failures-only-success": {
+			opts: []ctxgrpc.InterceptorOption{ctxgrpc.WithFailuresOnly()},
+		},
+		"failures-only-failure": {
+			opts:   []ctxgrpc.InterceptorOption{ctxgrpc.WithFailuresOnly()},
+			err:    status.Error(codes.Internal, "boom"),
+			logged: true,
+		},
+		"slow-fast": {
+			opts: []ctxgrpc.InterceptorOption{ctxgrpc.WithSlowThreshold(time.Hour)},
+		},
+		"slow-slow": {
+			opts:   []ctxgrpc.InterceptorOption{ctxgrpc.WithSlowThreshold(time.Millisecond)},
+			delay:  10 * time.Millisecond,
+			logged: true,
+		},
+		"both-failure": {
+			opts:   []ctxgrpc.InterceptorOption{ctxgrpc.WithFailuresOnly(), ctxgrpc.WithSlowThreshold(time.Hour)},
+			err:    status.Error(codes.Internal, "boom"),
+			logged: true,
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			interceptor := ctxgrpc.NewUnaryClientInterceptor(sklog.NewLogger(log.NewJSONLogger(b), sklog.WithStackTraceDepth(0)), c.opts...)
+
+			interceptor(context.Background(), "/example.Service/Method", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				time.Sleep(c.delay)
+				return c.err
+			})
+
+			assert.Equal(t, c.logged, b.Len() > 0)
+		})
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	messages int
+}
+
+func (cs *clientStream) SendMsg(m interface{}) error {
+	return nil
+}
+
+func (cs *clientStream) CloseSend() error {
+	return nil
+}
+
+func (cs *clientStream) RecvMsg(m interface{}) error {
+	if cs.messages == 0 {
+		return io.EOF
+	}
+	cs.messages--
+	return nil
+}
+
+func TestNewStreamClientInterceptor(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewStreamClientInterceptor(sklog.NewLogger(log.NewJSONLogger(b)))
+
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, "/example.Service/Stream", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &clientStream{messages: 2}, nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, stream.SendMsg(nil))
+	for stream.RecvMsg(nil) == nil {
+	}
+	assert.Equal(t, io.EOF, stream.RecvMsg(nil))
+
+	assert.Contains(t, b.String(), `"msg":"stream completed"`)
+	assert.Contains(t, b.String(), `"code":"OK"`)
+	assert.Contains(t, b.String(), `"grpc_messages_received":2`)
+	assert.Contains(t, b.String(), `"grpc_messages_sent":1`)
+	assert.Equal(t, 1, bytes.Count(b.Bytes(), []byte("\n")))
+}
+
+func TestNewStreamClientInterceptor_clientStreaming(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewStreamClientInterceptor(sklog.NewLogger(log.NewJSONLogger(b)))
+
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{ClientStreams: true}, nil, "/example.Service/Upload", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &clientStream{messages: 1}, nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// The same sequence of calls that generated CloseAndRecv methods make.
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, stream.SendMsg(nil))
+	}
+	assert.NoError(t, stream.CloseSend())
+	assert.NoError(t, stream.RecvMsg(nil))
+
+	assert.Contains(t, b.String(), `"code":"OK"`)
+	assert.Contains(t, b.String(), `"grpc_messages_received":1`)
+	assert.Contains(t, b.String(), `"grpc_messages_sent":3`)
+	assert.Equal(t, 1, bytes.Count(b.Bytes(), []byte("\n")))
+}
+
+func TestNewStreamClientInterceptor_canceled(t *testing.T) {
+	records := make(chan []interface{}, 1)
+	interceptor := ctxgrpc.NewStreamClientInterceptor(sklog.NewLogger(log.LoggerFunc(func(keyvals ...interface{}) error {
+		records <- keyvals
+		return nil
+	})))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := interceptor(ctx, &grpc.StreamDesc{ServerStreams: true}, nil, "/example.Service/Stream", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &clientStream{messages: 5}, nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Stream is abandoned after the first message, concurrently with the cancellation.
+	go stream.SendMsg(nil)
+	assert.NoError(t, stream.RecvMsg(nil))
+	cancel()
+
+	select {
+	case keyvals := <-records:
+		assert.Contains(t, keyvals, "Canceled")
+		assert.Contains(t, keyvals, sklog.LevelWarning)
+	case <-time.After(time.Second):
+		t.Fatal("stream was not logged after the context was canceled")
+	}
+}
+
+func TestNewStreamClientInterceptor_error(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewStreamClientInterceptor(sklog.NewLogger(log.NewJSONLogger(b), sklog.WithStackTraceDepth(0)))
+
+	_, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/example.Service/Stream", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, b.String(), `"level":"warn"`)
+	assert.Contains(t, b.String(), `"msg":"stream completed"`)
+	assert.Contains(t, b.String(), `"grpc_status_message":"access denied"`)
+	assert.Contains(t, b.String(), `"code":"PermissionDenied"`)
+}
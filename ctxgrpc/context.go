@@ -3,15 +3,114 @@ package ctxgrpc
 import (
 	"github.com/go-kit/kit/log"
 	"github.com/piotrkowalczuk/sklog"
-	"google.golang.org/grpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// NewContextErrorGeneric ...
+const (
+	// KeyFieldViolations ...
+	KeyFieldViolations = "grpc_field_violations"
+	// KeyErrorReason ...
+	KeyErrorReason = "grpc_error_reason"
+	// KeyErrorDomain ...
+	KeyErrorDomain = "grpc_error_domain"
+	// KeyErrorMetadata ...
+	KeyErrorMetadata = "grpc_error_metadata"
+	// KeyRetryDelay ...
+	KeyRetryDelay = "grpc_retry_delay"
+	// KeyQuotaViolations ...
+	KeyQuotaViolations = "grpc_quota_violations"
+	// KeyDebugStack ...
+	KeyDebugStack = "grpc_debug_stack"
+	// KeyDebugDetail ...
+	KeyDebugDetail = "grpc_debug_detail"
+	// KeyResourceType ...
+	KeyResourceType = "grpc_resource_type"
+	// KeyResourceName ...
+	KeyResourceName = "grpc_resource_name"
+	// KeyResourceOwner ...
+	KeyResourceOwner = "grpc_resource_owner"
+	// KeyResourceDescription ...
+	KeyResourceDescription = "grpc_resource_description"
+)
+
+type statusError interface {
+	error
+	GRPCStatus() *status.Status
+}
+
+// Register registers context error handler for gRPC status errors in given registry.
+func Register(r *sklog.ContextErrorRegistry) {
+	sklog.RegisterContextError(r, newContextStatusError)
+}
+
+func newContextStatusError(logger log.Logger, err statusError) *log.Context {
+	return NewContextErrorGeneric(logger, err)
+}
+
+// NewContextErrorGeneric creates context with status message and code (unless it is Unknown).
+// Details attached to the status (google.rpc.BadRequest, ErrorInfo, RetryInfo, QuotaFailure, DebugInfo
+// and ResourceInfo) are decoded into grpc_* fields.
 func NewContextErrorGeneric(logger log.Logger, err error) *log.Context {
-	if code := grpc.Code(err); code != codes.Unknown {
-		return log.NewContext(logger).With(sklog.KeyMessage, grpc.ErrorDesc(err), "code", code.String())
+	st, _ := status.FromError(err)
+
+	ctx := log.NewContext(logger).With(sklog.KeyMessage, st.Message())
+	if st.Code() != codes.Unknown {
+		ctx = ctx.With(KeyCode, st.Code().String())
+	}
+	if kv := detailsKeyvals(st.Details()); len(kv) > 0 {
+		ctx = ctx.With(kv...)
+	}
+
+	return ctx
+}
+
+func detailsKeyvals(details []interface{}) []interface{} {
+	var kv []interface{}
+	for _, detail := range details {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			violations := make([]string, 0, len(d.GetFieldViolations()))
+			for _, v := range d.GetFieldViolations() {
+				violations = append(violations, v.GetField()+": "+v.GetDescription())
+			}
+			kv = append(kv, KeyFieldViolations, violations)
+		case *errdetails.ErrorInfo:
+			kv = append(kv, KeyErrorReason, d.GetReason(), KeyErrorDomain, d.GetDomain())
+			if len(d.GetMetadata()) > 0 {
+				kv = append(kv, KeyErrorMetadata, d.GetMetadata())
+			}
+		case *errdetails.RetryInfo:
+			if d.GetRetryDelay() != nil {
+				kv = append(kv, KeyRetryDelay, d.GetRetryDelay().AsDuration())
+			}
+		case *errdetails.QuotaFailure:
+			violations := make([]string, 0, len(d.GetViolations()))
+			for _, v := range d.GetViolations() {
+				violations = append(violations, v.GetSubject()+": "+v.GetDescription())
+			}
+			kv = append(kv, KeyQuotaViolations, violations)
+		case *errdetails.DebugInfo:
+			if len(d.GetStackEntries()) > 0 {
+				kv = append(kv, KeyDebugStack, d.GetStackEntries())
+			}
+			if d.GetDetail() != "" {
+				kv = append(kv, KeyDebugDetail, d.GetDetail())
+			}
+		case *errdetails.ResourceInfo:
+			kv = append(kv,
+				KeyResourceType, d.GetResourceType(),
+				KeyResourceName, d.GetResourceName(),
+			)
+			if d.GetOwner() != "" {
+				kv = append(kv, KeyResourceOwner, d.GetOwner())
+			}
+			if d.GetDescription() != "" {
+				kv = append(kv, KeyResourceDescription, d.GetDescription())
+			}
+		}
 	}
 
-	return log.NewContext(logger).With(sklog.KeyMessage, grpc.ErrorDesc(err))
+	return kv
 }
@@ -0,0 +1,66 @@
+package ctxgrpc_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/piotrkowalczuk/sklog/ctxgrpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestNewContextErrorGeneric(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLogger(log.NewJSONLogger(b), sklog.WithContextErrorFunc(ctxgrpc.NewContextErrorGeneric), sklog.WithStackTraceDepth(0))
+
+	l.Error(status.Error(codes.NotFound, "user does not exist"))
+	assert.Contains(t, b.String(), `"code":"NotFound"`)
+	b.Reset()
+
+	l.Error(errors.New("ctxgrpc_test: example generic error"))
+	assert.Contains(t, b.String(), `"msg":"ctxgrpc_test: example generic error"`)
+	assert.NotContains(t, b.String(), `"code"`)
+}
+
+func TestNewContextErrorGeneric_details(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid request").WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "email", Description: "is required"},
+		}},
+		&errdetails.ErrorInfo{Reason: "EMAIL_MISSING", Domain: "example.com", Metadata: map[string]string{"form": "signup"}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(5 * time.Second)},
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "project:example", Description: "daily limit exceeded"},
+		}},
+		&errdetails.DebugInfo{StackEntries: []string{"main.go:10"}, Detail: "nil pointer"},
+		&errdetails.ResourceInfo{ResourceType: "user", ResourceName: "john", Owner: "team", Description: "missing"},
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLogger(log.NewJSONLogger(b), sklog.WithContextErrorFunc(ctxgrpc.NewContextErrorGeneric), sklog.WithStackTraceDepth(0))
+	l.Error(st.Err())
+
+	assert.Contains(t, b.String(), `"code":"InvalidArgument"`)
+	assert.Contains(t, b.String(), `"grpc_field_violations":["email: is required"]`)
+	assert.Contains(t, b.String(), `"grpc_error_reason":"EMAIL_MISSING"`)
+	assert.Contains(t, b.String(), `"grpc_error_domain":"example.com"`)
+	assert.Contains(t, b.String(), `"grpc_error_metadata":{"form":"signup"}`)
+	assert.Contains(t, b.String(), `"grpc_retry_delay":"5s"`)
+	assert.Contains(t, b.String(), `"grpc_quota_violations":["project:example: daily limit exceeded"]`)
+	assert.Contains(t, b.String(), `"grpc_debug_stack":["main.go:10"]`)
+	assert.Contains(t, b.String(), `"grpc_debug_detail":"nil pointer"`)
+	assert.Contains(t, b.String(), `"grpc_resource_type":"user"`)
+	assert.Contains(t, b.String(), `"grpc_resource_name":"john"`)
+	assert.Contains(t, b.String(), `"grpc_resource_owner":"team"`)
+	assert.Contains(t, b.String(), `"grpc_resource_description":"missing"`)
+}
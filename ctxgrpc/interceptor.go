@@ -0,0 +1,251 @@
+package ctxgrpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/sklog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// KeyMethod ...
+	KeyMethod = "grpc_method"
+	// KeyCode ...
+	KeyCode = "code"
+	// KeyStatusMessage is a key under which message of a status other than OK is logged.
+	KeyStatusMessage = "grpc_status_message"
+	// KeyPeer ...
+	KeyPeer = "grpc_peer"
+	// KeyDuration ...
+	KeyDuration = "grpc_duration"
+	// KeyRequestSize ...
+	KeyRequestSize = "grpc_request_size"
+	// KeyResponseSize ...
+	KeyResponseSize = "grpc_response_size"
+	// KeyRequest ...
+	KeyRequest = "grpc_request"
+	// KeyResponse ...
+	KeyResponse = "grpc_response"
+	// KeyMessagesReceived ...
+	KeyMessagesReceived = "grpc_messages_received"
+	// KeyMessagesSent ...
+	KeyMessagesSent = "grpc_messages_sent"
+	// KeyTarget ...
+	KeyTarget = "grpc_target"
+	// KeyAttempt ...
+	KeyAttempt = "grpc_attempt"
+	// KeyDeadline is a key under which time remaining until the deadline is logged.
+	KeyDeadline = "grpc_deadline"
+	// KeyMetadataPrefix is a prefix of keys under which selected metadata is logged.
+	KeyMetadataPrefix = "grpc_metadata_"
+
+	// MetadataRequestID is a metadata key that is expected to carry request id.
+	MetadataRequestID = "x-request-id"
+)
+
+// NewContextUnaryServerInterceptor returns interceptor that stores given logger in the request context,
+// together with request id (taken from x-request-id metadata) and method name.
+// Handlers can retrieve it using sklog.FromContext.
+// Request fields added by another ctxgrpc interceptor up the chain are not added again.
+func NewContextUnaryServerInterceptor(logger *sklog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(newRequestContext(ctx, logger, info.FullMethod), req)
+	}
+}
+
+// NewContextStreamServerInterceptor works like NewContextUnaryServerInterceptor but for streams.
+func NewContextStreamServerInterceptor(logger *sklog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{
+			ServerStream: ss,
+			ctx:          newRequestContext(ss.Context(), logger, info.FullMethod),
+		})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	received int
+	sent     int
+}
+
+// Context implements grpc.ServerStream interface.
+func (ss *serverStream) Context() context.Context {
+	return ss.ctx
+}
+
+// RecvMsg implements grpc.ServerStream interface.
+func (ss *serverStream) RecvMsg(m interface{}) error {
+	err := ss.ServerStream.RecvMsg(m)
+	if err == nil {
+		ss.received++
+	}
+
+	return err
+}
+
+// SendMsg implements grpc.ServerStream interface.
+func (ss *serverStream) SendMsg(m interface{}) error {
+	err := ss.ServerStream.SendMsg(m)
+	if err == nil {
+		ss.sent++
+	}
+
+	return err
+}
+
+// requestContextKey marks a context that carries request key/value pairs already.
+type requestContextKey struct{}
+
+// newRequestContext stores given logger in the request context. Request id and method are added
+// unless an interceptor up the chain did it already.
+func newRequestContext(ctx context.Context, logger *sklog.Logger, method string) context.Context {
+	ctx = sklog.ContextWithLogger(ctx, logger)
+	if ctx.Value(requestContextKey{}) != nil {
+		return ctx
+	}
+	if id := requestID(ctx); id != "" {
+		ctx = sklog.ContextWithKeyvals(ctx, sklog.KeyRequestID, id)
+	}
+	ctx = sklog.ContextWithKeyvals(ctx, KeyMethod, method)
+
+	return context.WithValue(ctx, requestContextKey{}, true)
+}
+
+func requestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	return strings.Join(md.Get(MetadataRequestID), ",")
+}
+
+// InterceptorOption configures logging interceptors.
+type InterceptorOption func(*interceptorOptions)
+
+type interceptorOptions struct {
+	metadata     []string
+	payloads     bool
+	failuresOnly bool
+	slow         time.Duration
+}
+
+// WithMetadata makes interceptor log values of given metadata keys, each under KeyMetadataPrefix+key.
+func WithMetadata(keys ...string) InterceptorOption {
+	return func(o *interceptorOptions) {
+		for _, key := range keys {
+			o.metadata = append(o.metadata, strings.ToLower(key))
+		}
+	}
+}
+
+// WithPayloads makes unary interceptors log request and response messages.
+func WithPayloads() InterceptorOption {
+	return func(o *interceptorOptions) {
+		o.payloads = true
+	}
+}
+
+// WithFailuresOnly makes client interceptors log only calls that ended with status other than OK.
+// If combined with WithSlowThreshold, calls that are either failed or slow are logged.
+func WithFailuresOnly() InterceptorOption {
+	return func(o *interceptorOptions) {
+		o.failuresOnly = true
+	}
+}
+
+// WithSlowThreshold makes client interceptors log only calls that took longer than given threshold.
+// If combined with WithFailuresOnly, calls that are either failed or slow are logged.
+func WithSlowThreshold(threshold time.Duration) InterceptorOption {
+	return func(o *interceptorOptions) {
+		o.slow = threshold
+	}
+}
+
+func newInterceptorOptions(opts []InterceptorOption) *interceptorOptions {
+	o := &interceptorOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+func (o *interceptorOptions) appendMetadata(kv []interface{}, md metadata.MD) []interface{} {
+	for _, key := range o.metadata {
+		if values := md.Get(key); len(values) > 0 {
+			kv = append(kv, KeyMetadataPrefix+key, strings.Join(values, ","))
+		}
+	}
+
+	return kv
+}
+
+// shouldLog reports whether call that ended with given error after given time should be logged.
+func (o *interceptorOptions) shouldLog(err error, elapsed time.Duration) bool {
+	if !o.failuresOnly && o.slow <= 0 {
+		return true
+	}
+
+	return (o.failuresOnly && err != nil) || (o.slow > 0 && elapsed > o.slow)
+}
+
+// Level returns level that a call that ended with given code should be logged with.
+// Codes caused by the client are logged as warnings, those that indicate server problems as errors.
+func Level(code codes.Code) string {
+	switch code {
+	case codes.OK:
+		return sklog.LevelInfo
+	case codes.Canceled,
+		codes.InvalidArgument,
+		codes.NotFound,
+		codes.AlreadyExists,
+		codes.PermissionDenied,
+		codes.Unauthenticated,
+		codes.ResourceExhausted,
+		codes.FailedPrecondition,
+		codes.Aborted,
+		codes.OutOfRange:
+		return sklog.LevelWarning
+	default:
+		return sklog.LevelError
+	}
+}
+
+// logResult logs result of a call using level derived from its status code.
+// Message stays the same regardless of the outcome, status message and details are logged as fields.
+func logResult(logger *sklog.Logger, msg string, err error, kv []interface{}) {
+	st := status.Convert(err)
+	kv = append(kv, KeyCode, st.Code().String())
+	if err != nil {
+		kv = append(kv, KeyStatusMessage, st.Message())
+		kv = append(kv, detailsKeyvals(st.Details())...)
+	}
+
+	logger.LogLevel(Level(st.Code()), msg, kv...)
+}
+
+func payload(v interface{}) interface{} {
+	if m, ok := v.(proto.Message); ok {
+		return protojson.Format(m)
+	}
+
+	return v
+}
+
+func size(v interface{}) (int, bool) {
+	if m, ok := v.(proto.Message); ok {
+		return proto.Size(m), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,282 @@
+package ctxgrpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/piotrkowalczuk/sklog/ctxgrpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss *serverStream) Context() context.Context {
+	return ss.ctx
+}
+
+func TestNewContextUnaryServerInterceptor(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewContextUnaryServerInterceptor(sklog.NewLogger(log.NewJSONLogger(b)))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ctxgrpc.MetadataRequestID, "abc"))
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/example.Service/Method"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		sklog.InfoCtx(ctx, "handled")
+		return nil, nil
+	})
+
+	if assert.NoError(t, err) {
+		assert.Contains(t, b.String(), `"msg":"handled"`)
+		assert.Contains(t, b.String(), `"request_id":"abc"`)
+		assert.Contains(t, b.String(), `"grpc_method":"/example.Service/Method"`)
+	}
+}
+
+func TestNewContextUnaryServerInterceptor_stacked(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLogger(log.NewLogfmtLogger(b))
+	outer := ctxgrpc.NewContextUnaryServerInterceptor(l)
+	inner := ctxgrpc.NewContextUnaryServerInterceptor(l)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ctxgrpc.MetadataRequestID, "abc"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/example.Service/Method"}
+
+	_, err := outer(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return inner(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			sklog.InfoCtx(ctx, "handled")
+			return nil, nil
+		})
+	})
+
+	if assert.NoError(t, err) {
+		assert.Contains(t, b.String(), "msg=handled")
+		assert.Equal(t, 1, strings.Count(b.String(), "request_id=abc"))
+		assert.Equal(t, 1, strings.Count(b.String(), "grpc_method=/example.Service/Method"))
+	}
+}
+
+func TestNewContextStreamServerInterceptor(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewContextStreamServerInterceptor(sklog.NewLogger(log.NewJSONLogger(b)))
+	ss := &serverStream{ctx: context.Background()}
+
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/example.Service/Stream"}, func(srv interface{}, ss grpc.ServerStream) error {
+		sklog.InfoCtx(ss.Context(), "handled")
+		return nil
+	})
+
+	if assert.NoError(t, err) {
+		assert.Contains(t, b.String(), `"msg":"handled"`)
+		assert.Contains(t, b.String(), `"grpc_method":"/example.Service/Stream"`)
+		assert.NotContains(t, b.String(), sklog.KeyRequestID)
+	}
+}
+
+func TestNewUnaryServerInterceptor(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewUnaryServerInterceptor(
+		sklog.NewLogger(log.NewJSONLogger(b)),
+		ctxgrpc.WithMetadata("User-Agent"),
+		ctxgrpc.WithPayloads(),
+	)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", "test"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}})
+
+	res, err := interceptor(ctx, wrapperspb.String("ping"), &grpc.UnaryServerInfo{FullMethod: "/example.Service/Method"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return wrapperspb.String("pong"), nil
+	})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "pong", res.(*wrapperspb.StringValue).GetValue())
+		assert.Contains(t, b.String(), `"level":"info"`)
+		assert.Contains(t, b.String(), `"code":"OK"`)
+		assert.Contains(t, b.String(), `"grpc_method":"/example.Service/Method"`)
+		assert.Contains(t, b.String(), `"grpc_peer":"127.0.0.1:8080"`)
+		assert.Contains(t, b.String(), `"grpc_metadata_user-agent":"test"`)
+		assert.Contains(t, b.String(), `"grpc_request_size":6`)
+		assert.Contains(t, b.String(), `"grpc_response_size":6`)
+		assert.Contains(t, b.String(), `"grpc_request":"\"ping\""`)
+		assert.Contains(t, b.String(), `"grpc_response":"\"pong\""`)
+		assert.Contains(t, b.String(), `"grpc_duration"`)
+	}
+}
+
+func TestNewUnaryServerInterceptor_error(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"not-found": {
+			err:      status.Error(codes.NotFound, "user does not exist"),
+			expected: `"grpc_status_message":"user does not exist","level":"warn","msg":"request processed"`,
+		},
+		"internal": {
+			err:      status.Error(codes.Internal, "database is down"),
+			expected: `"grpc_status_message":"database is down","level":"error","msg":"request processed"`,
+		},
+		"unknown": {
+			err:      errors.New("something went wrong"),
+			expected: `"grpc_status_message":"something went wrong","level":"error","msg":"request processed"`,
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			interceptor := ctxgrpc.NewUnaryServerInterceptor(sklog.NewLogger(log.NewJSONLogger(b), sklog.WithStackTraceDepth(0)))
+
+			_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/example.Service/Method"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, c.err
+			})
+
+			assert.Equal(t, c.err, err)
+			assert.Contains(t, b.String(), c.expected)
+			assert.Equal(t, 1, strings.Count(b.String(), "\n"))
+			assert.NotContains(t, b.String(), sklog.KeyStackTrace)
+		})
+	}
+}
+
+func TestNewUnaryServerInterceptor_caller(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewUnaryServerInterceptor(sklog.NewLogger(log.NewJSONLogger(b), sklog.WithCallerMode(sklog.CallerFunc)))
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/example.Service/Method"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+
+	if assert.NoError(t, err) {
+		assert.Contains(t, b.String(), `"caller":"interceptor_test.go:`)
+		assert.Contains(t, b.String(), `"func":"ctxgrpc_test.TestNewUnaryServerInterceptor_caller"`)
+	}
+}
+
+type countingStream struct {
+	serverStream
+	messages int
+}
+
+func (cs *countingStream) RecvMsg(m interface{}) error {
+	if cs.messages == 0 {
+		return io.EOF
+	}
+	cs.messages--
+	return nil
+}
+
+func (cs *countingStream) SendMsg(m interface{}) error {
+	return nil
+}
+
+func TestNewStreamServerInterceptor(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewStreamServerInterceptor(sklog.NewLogger(log.NewJSONLogger(b)))
+	ss := &countingStream{serverStream: serverStream{ctx: context.Background()}, messages: 3}
+
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/example.Service/Stream"}, func(srv interface{}, ss grpc.ServerStream) error {
+		for {
+			if err := ss.RecvMsg(nil); err != nil {
+				break
+			}
+		}
+		return ss.SendMsg(nil)
+	})
+
+	if assert.NoError(t, err) {
+		assert.Contains(t, b.String(), `"msg":"stream processed"`)
+		assert.Contains(t, b.String(), `"grpc_messages_received":3`)
+		assert.Contains(t, b.String(), `"grpc_messages_sent":1`)
+	}
+}
+
+func TestLevel(t *testing.T) {
+	assert.Equal(t, sklog.LevelInfo, ctxgrpc.Level(codes.OK))
+	assert.Equal(t, sklog.LevelWarning, ctxgrpc.Level(codes.InvalidArgument))
+	assert.Equal(t, sklog.LevelError, ctxgrpc.Level(codes.Unavailable))
+	assert.Equal(t, sklog.LevelError, ctxgrpc.Level(codes.Unknown))
+}
+
+func TestNewRecoveryUnaryServerInterceptor(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewRecoveryUnaryServerInterceptor(sklog.NewLogger(log.NewJSONLogger(b)))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ctxgrpc.MetadataRequestID, "abc"))
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/example.Service/Method"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("out of range")
+	})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Contains(t, b.String(), `"level":"panic"`)
+	assert.Contains(t, b.String(), `"msg":"out of range"`)
+	assert.Contains(t, b.String(), `"request_id":"abc"`)
+	assert.Contains(t, b.String(), `"grpc_method":"/example.Service/Method"`)
+	assert.Contains(t, b.String(), "TestNewRecoveryUnaryServerInterceptor")
+	assert.NotContains(t, b.String(), "ctxgrpc.recoverPanic")
+
+	var record struct {
+		StackTrace []string `json:"stacktrace"`
+	}
+	if assert.NoError(t, json.Unmarshal(b.Bytes(), &record)) && assert.NotEmpty(t, record.StackTrace) {
+		assert.True(t, strings.HasPrefix(record.StackTrace[0], "ctxgrpc_test.TestNewRecoveryUnaryServerInterceptor.func1 "), record.StackTrace[0])
+	}
+}
+
+func TestNewRecoveryUnaryServerInterceptor_chained(t *testing.T) {
+	outer, inner := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	logging := ctxgrpc.NewUnaryServerInterceptor(sklog.NewLogger(log.NewLogfmtLogger(outer)))
+	recovery := ctxgrpc.NewRecoveryUnaryServerInterceptor(sklog.NewLogger(log.NewLogfmtLogger(inner)))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ctxgrpc.MetadataRequestID, "abc"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/example.Service/Method"}
+
+	_, err := logging(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return recovery(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("out of range")
+		})
+	})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Empty(t, inner.String())
+	lines := strings.Split(strings.TrimSpace(outer.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Contains(t, lines[0], "level=panic")
+		assert.Contains(t, lines[1], `msg="request processed"`)
+		for _, line := range lines {
+			assert.Equal(t, 1, strings.Count(line, "request_id=abc"), line)
+			assert.Equal(t, 1, strings.Count(line, "grpc_method=/example.Service/Method"), line)
+		}
+	}
+}
+
+func TestNewRecoveryStreamServerInterceptor(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	interceptor := ctxgrpc.NewRecoveryStreamServerInterceptor(sklog.NewLogger(log.NewJSONLogger(b)))
+	ss := &serverStream{ctx: context.Background()}
+
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/example.Service/Stream"}, func(srv interface{}, ss grpc.ServerStream) error {
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, b.String())
+
+	err = interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/example.Service/Stream"}, func(srv interface{}, ss grpc.ServerStream) error {
+		panic(errors.New("nil map"))
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Contains(t, b.String(), `"level":"panic"`)
+	assert.Contains(t, b.String(), `"msg":"nil map"`)
+}
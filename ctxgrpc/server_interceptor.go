@@ -0,0 +1,120 @@
+package ctxgrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/piotrkowalczuk/sklog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// NewUnaryServerInterceptor returns interceptor that logs every call with its method, peer, duration,
+// status code and request size. Level is derived from the status code (see Level).
+// Like NewContextUnaryServerInterceptor, it stores logger in the request context.
+func NewUnaryServerInterceptor(logger *sklog.Logger, opts ...InterceptorOption) grpc.UnaryServerInterceptor {
+	o := newInterceptorOptions(opts)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx = newRequestContext(ctx, logger, info.FullMethod)
+
+		res, err := handler(ctx, req)
+
+		kv := serverKeyvals(ctx, o, start)
+		if n, ok := size(req); ok {
+			kv = append(kv, KeyRequestSize, n)
+		}
+		if n, ok := size(res); ok && err == nil {
+			kv = append(kv, KeyResponseSize, n)
+		}
+		if o.payloads {
+			kv = append(kv, KeyRequest, payload(req))
+			if err == nil {
+				kv = append(kv, KeyResponse, payload(res))
+			}
+		}
+		logResult(sklog.FromContext(ctx), "request processed", err, kv)
+
+		return res, err
+	}
+}
+
+// NewStreamServerInterceptor works like NewUnaryServerInterceptor but for streams.
+// Instead of sizes it logs number of messages received and sent.
+func NewStreamServerInterceptor(logger *sklog.Logger, opts ...InterceptorOption) grpc.StreamServerInterceptor {
+	o := newInterceptorOptions(opts)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		wrapped := &serverStream{
+			ServerStream: ss,
+			ctx:          newRequestContext(ss.Context(), logger, info.FullMethod),
+		}
+
+		err := handler(srv, wrapped)
+
+		kv := serverKeyvals(wrapped.ctx, o, start)
+		kv = append(kv, KeyMessagesReceived, wrapped.received, KeyMessagesSent, wrapped.sent)
+		logResult(sklog.FromContext(wrapped.ctx), "stream processed", err, kv)
+
+		return err
+	}
+}
+
+func serverKeyvals(ctx context.Context, o *interceptorOptions, start time.Time) []interface{} {
+	kv := []interface{}{KeyDuration, time.Since(start)}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		kv = append(kv, KeyPeer, p.Addr.String())
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		kv = o.appendMetadata(kv, md)
+	}
+
+	return kv
+}
+
+// NewRecoveryUnaryServerInterceptor returns interceptor that recovers from panics that occur in handlers.
+// Recovered value is logged with level panic (see sklog.Logger.Recovered) together with stack trace,
+// request id and method, and the client receives Internal error.
+// Logger and fields already stored in the request context are reused, given logger is used only if there is none.
+func NewRecoveryUnaryServerInterceptor(logger *sklog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+		ctx = newRecoveryContext(ctx, logger, info.FullMethod)
+		defer recoverPanic(ctx, &err)
+
+		return handler(ctx, req)
+	}
+}
+
+// NewRecoveryStreamServerInterceptor works like NewRecoveryUnaryServerInterceptor but for streams.
+func NewRecoveryStreamServerInterceptor(logger *sklog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		wrapped := &serverStream{
+			ServerStream: ss,
+			ctx:          newRecoveryContext(ss.Context(), logger, info.FullMethod),
+		}
+		defer recoverPanic(wrapped.ctx, &err)
+
+		return handler(srv, wrapped)
+	}
+}
+
+// newRecoveryContext works like newRequestContext, but prefers logger already stored in the context.
+func newRecoveryContext(ctx context.Context, logger *sklog.Logger, method string) context.Context {
+	if l, ok := sklog.LoggerFromContext(ctx); ok {
+		logger = l
+	}
+
+	return newRequestContext(ctx, logger, method)
+}
+
+func recoverPanic(ctx context.Context, err *error) {
+	if v := recover(); v != nil {
+		sklog.FromContext(ctx).Recovered(v)
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
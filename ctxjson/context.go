@@ -7,33 +7,34 @@ import (
 	"github.com/piotrkowalczuk/sklog"
 )
 
+var registry = sklog.NewContextErrorRegistry()
+
+func init() {
+	Register(registry)
+}
+
+// Register registers context error handlers for encoding/json errors in given registry.
+func Register(r *sklog.ContextErrorRegistry) {
+	sklog.RegisterContextError(r, newContextContexter)
+	sklog.RegisterContextError(r, NewContextJSONMarshalerError)
+	sklog.RegisterContextError(r, NewContextJSONInvalidUnmarshalError)
+	sklog.RegisterContextError(r, NewContextJSONUnmarshalFieldError)
+	sklog.RegisterContextError(r, NewContextJSONUnmarshalTypeError)
+	sklog.RegisterContextError(r, NewContextJSONUnsupportedTypeError)
+	sklog.RegisterContextError(r, NewContextJSONUnsupportedValueError)
+	sklog.RegisterContextError(r, NewContextJSONInvalidUTF8Error)
+	sklog.RegisterContextError(r, NewContextJSONSyntaxError)
+}
+
 // NewContextErrorGeneric creates context for given error.
 // Performs error type check internally to choose strategy that fits the best.
+// Wrapped errors are inspected as well, using errors.As.
 func NewContextError(logger log.Logger, err error) *log.Context {
-	if ctx, ok := err.(sklog.Contexter); ok {
-		return log.NewContext(logger).With(ctx.Context())
-	}
+	return registry.NewContextError(logger, err)
+}
 
-	switch e := err.(type) {
-	case *json.MarshalerError:
-		return NewContextJSONMarshalerError(logger, e)
-	case *json.InvalidUnmarshalError:
-		return NewContextJSONInvalidUnmarshalError(logger, e)
-	case *json.UnmarshalFieldError:
-		return NewContextJSONUnmarshalFieldError(logger, e)
-	case *json.UnmarshalTypeError:
-		return NewContextJSONUnmarshalTypeError(logger, e)
-	case *json.UnsupportedTypeError:
-		return NewContextJSONUnsupportedTypeError(logger, e)
-	case *json.UnsupportedValueError:
-		return NewContextJSONUnsupportedValueError(logger, e)
-	case *json.InvalidUTF8Error:
-		return NewContextJSONInvalidUTF8Error(logger, e)
-	case *json.SyntaxError:
-		return NewContextJSONSyntaxError(logger, e)
-	default:
-		return sklog.NewContextErrorGeneric(logger, e)
-	}
+func newContextContexter(logger log.Logger, ctx sklog.Contexter) *log.Context {
+	return log.NewContext(logger).With(ctx.Context()...)
 }
 
 // NewContextJSONUnmarshalTypeError ...
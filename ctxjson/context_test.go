@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -168,3 +169,14 @@ var successTestData = map[string]struct {
 		},
 	},
 }
+
+func TestNewContextError_wrapped(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := log.NewJSONLogger(b)
+
+	err := fmt.Errorf("ctxjson_test: wrapped: %w", &json.SyntaxError{Offset: 7})
+	if assert.NoError(t, ctxjson.NewContextError(l, err).Log()) {
+		assert.Contains(t, b.String(), `"json_offset":7`)
+		assert.Contains(t, b.String(), `"`+sklog.KeyErrorChain+`":["ctxjson_test: wrapped: ","`)
+	}
+}
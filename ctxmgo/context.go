@@ -1,6 +1,8 @@
 package ctxmgo
 
 import (
+	"errors"
+
 	"github.com/go-kit/kit/log"
 	"github.com/piotrkowalczuk/sklog"
 	"gopkg.in/mgo.v2"
@@ -14,10 +16,16 @@ func NewContextQueryError(logger log.Logger, err *mgo.QueryError) *log.Context {
 	)
 }
 
+// Register registers context error handler for *mgo.QueryError in given registry.
+func Register(r *sklog.ContextErrorRegistry) {
+	sklog.RegisterContextError(r, NewContextQueryError)
+}
+
 // NewContextErrorGeneric ...
 func NewContextErrorGeneric(logger log.Logger, err error) *log.Context {
-	if mgoe, ok := err.(*mgo.QueryError); ok {
-		return NewContextQueryError(logger, mgoe)
+	var mgoe *mgo.QueryError
+	if errors.As(err, &mgoe) {
+		return sklog.WithErrorChain(NewContextQueryError(logger, mgoe), err)
 	}
 
 	return sklog.NewContextErrorGeneric(logger, err)
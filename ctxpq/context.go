@@ -1,6 +1,8 @@
 package ctxpq
 
 import (
+	"errors"
+
 	"github.com/go-kit/kit/log"
 	"github.com/lib/pq"
 	"github.com/piotrkowalczuk/sklog"
@@ -28,10 +30,16 @@ func NewContextError(logger log.Logger, err *pq.Error) *log.Context {
 	)
 }
 
+// Register registers context error handler for *pq.Error in given registry.
+func Register(r *sklog.ContextErrorRegistry) {
+	sklog.RegisterContextError(r, NewContextError)
+}
+
 // NewContextErrorGeneric ...
 func NewContextErrorGeneric(logger log.Logger, err error) *log.Context {
-	if pqe, ok := err.(*pq.Error); ok {
-		return NewContextError(logger, pqe)
+	var pqe *pq.Error
+	if errors.As(err, &pqe) {
+		return sklog.WithErrorChain(NewContextError(logger, pqe), err)
 	}
 
 	return sklog.NewContextErrorGeneric(logger, err)
@@ -40,6 +40,23 @@ func TestNewContextErrorGeneric(t *testing.T) {
 		b.Reset()
 	}
 
+	for _, e := range pqErrors {
+		w := fmt.Errorf("ctxpq_test: wrapped: %w", e)
+		sklog.Error(l, w)
+
+		assertPqError(t, b, w)
+		assert.Contains(t, b.String(), sklog.KeyErrorChain)
+		b.Reset()
+	}
+
+	for _, e := range pqErrors {
+		j := errors.Join(errors.New("ctxpq_test: joined"), e)
+		sklog.Error(l, j)
+
+		assertPqError(t, b, e)
+		b.Reset()
+	}
+
 	for _, e := range genericErrors {
 		sklog.Error(l, e)
 
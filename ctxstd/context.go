@@ -2,9 +2,11 @@ package ctxstd
 
 import (
 	"encoding/json"
+	"errors"
 	"go/scanner"
 	"net"
 	"net/textproto"
+	"net/url"
 	"os"
 	"reflect"
 
@@ -12,47 +14,45 @@ import (
 	"github.com/piotrkowalczuk/sklog"
 )
 
+var registry = sklog.NewContextErrorRegistry()
+
+func init() {
+	Register(registry)
+}
+
+// Register registers context error handlers for standard library errors in given registry.
+func Register(r *sklog.ContextErrorRegistry) {
+	sklog.RegisterContextError(r, newContextContexter)
+	sklog.RegisterContextError(r, NewContextReflectValueError)
+	// encoding/json
+	sklog.RegisterContextError(r, NewContextJSONMarshalerError)
+	sklog.RegisterContextError(r, NewContextJSONInvalidUnmarshalError)
+	sklog.RegisterContextError(r, NewContextJSONUnmarshalFieldError)
+	sklog.RegisterContextError(r, NewContextJSONUnmarshalTypeError)
+	sklog.RegisterContextError(r, NewContextJSONUnsupportedTypeError)
+	sklog.RegisterContextError(r, NewContextJSONUnsupportedValueError)
+	sklog.RegisterContextError(r, NewContextJSONInvalidUTF8Error)
+	sklog.RegisterContextError(r, NewContextJSONSyntaxError)
+	// net, outermost types go first: url.Error usually wraps net.OpError,
+	// which in turn wraps os.SyscallError
+	sklog.RegisterContextError(r, NewContextURLError)
+	sklog.RegisterContextError(r, NewContextNetOpError)
+	sklog.RegisterContextError(r, NewContextTextProtoError)
+	// os
+	sklog.RegisterContextError(r, NewContextOSPathError)
+	sklog.RegisterContextError(r, NewContextOSSyscallError)
+	sklog.RegisterContextError(r, NewContextScannerError)
+}
+
 // NewContextErrorGeneric creates context for given error.
 // Performs error type check internally to choose strategy that fits the best.
+// Wrapped errors are inspected as well, using errors.As.
 func NewContextErrorGeneric(logger log.Logger, err error) *log.Context {
-	if ctx, ok := err.(sklog.Contexter); ok {
-		return log.NewContext(logger).With(ctx.Context())
-	}
+	return registry.NewContextError(logger, err)
+}
 
-	switch e := err.(type) {
-	case *reflect.ValueError:
-		return NewContextReflectValueError(logger, e)
-	// encoding/json
-	case *json.MarshalerError:
-		return NewContextJSONMarshalerError(logger, e)
-	case *json.InvalidUnmarshalError:
-		return NewContextJSONInvalidUnmarshalError(logger, e)
-	case *json.UnmarshalFieldError:
-		return NewContextJSONUnmarshalFieldError(logger, e)
-	case *json.UnmarshalTypeError:
-		return NewContextJSONUnmarshalTypeError(logger, e)
-	case *json.UnsupportedTypeError:
-		return NewContextJSONUnsupportedTypeError(logger, e)
-	case *json.UnsupportedValueError:
-		return NewContextJSONUnsupportedValueError(logger, e)
-	case *json.InvalidUTF8Error:
-		return NewContextJSONInvalidUTF8Error(logger, e)
-	case *json.SyntaxError:
-		return NewContextJSONSyntaxError(logger, e)
-	// os
-	case *os.PathError:
-		return NewContextOSPathError(logger, e)
-	case *os.SyscallError:
-		return NewContextOSSyscallError(logger, e)
-	case *scanner.Error:
-		return NewContextScannerError(logger, e)
-	case *net.OpError:
-		return NewContextNetOpError(logger, e)
-	case *textproto.Error:
-		return NewContextTextProtoError(logger, e)
-	default:
-		return sklog.NewContextErrorGeneric(logger, e)
-	}
+func newContextContexter(logger log.Logger, ctx sklog.Contexter) *log.Context {
+	return log.NewContext(logger).With(ctx.Context()...)
 }
 
 // NewContextReflectValueError ...
@@ -162,6 +162,22 @@ func NewContextNetOpError(logger log.Logger, e *net.OpError) *log.Context {
 	)
 }
 
+// NewContextURLError ...
+func NewContextURLError(logger log.Logger, e *url.Error) *log.Context {
+	var ctx *log.Context
+	if op := (*net.OpError)(nil); errors.As(e.Err, &op) {
+		ctx = NewContextNetOpError(logger, op)
+	} else {
+		ctx = sklog.NewContextErrorGeneric(logger, e)
+	}
+
+	return ctx.With(
+		"url_op", e.Op,
+		"url_url", e.URL,
+		"url_timeout", e.Timeout(),
+	)
+}
+
 // NewContextTextProtoError ...
 func NewContextTextProtoError(logger log.Logger, e *textproto.Error) *log.Context {
 	return sklog.NewContextErrorGeneric(logger, e).With(
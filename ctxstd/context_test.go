@@ -4,7 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
 	"reflect"
+	"syscall"
 	"testing"
 
 	"github.com/go-kit/kit/log"
@@ -15,6 +20,7 @@ import (
 
 func TestNewContextErrorGeneric(t *testing.T) {
 	testNewContextErrorGeneric(t, successJSONCtxErrData)
+	testNewContextErrorGeneric(t, successWrappedCtxErrData)
 }
 
 func testNewContextErrorGeneric(t *testing.T, testData map[string]ctxErrData) {
@@ -173,3 +179,104 @@ var successJSONCtxErrData = map[string]ctxErrData{
 		},
 	},
 }
+
+var successWrappedCtxErrData = map[string]ctxErrData{
+	"URLError": {
+		error: &url.Error{
+			Op:  "Get",
+			URL: "http://example.com",
+			Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("ctxstd_test: connection refused")},
+		},
+		keys: []string{
+			"url_op",
+			"url_url",
+			"url_timeout",
+			"net_op",
+		},
+		values: []string{
+			"Get",
+			"http://example.com",
+			"dial",
+		},
+	},
+	"DialOpError": {
+		error: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+		keys: []string{
+			"net_op",
+			"net_net",
+			sklog.KeyErrorChain,
+		},
+		values: []string{
+			"dial",
+			"tcp",
+			"connect: connection refused",
+		},
+	},
+	"DialURLError": {
+		error: &url.Error{
+			Op:  "Get",
+			URL: "http://example.com",
+			Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+		},
+		keys: []string{
+			"url_op",
+			"url_url",
+			"net_op",
+			"net_net",
+		},
+		values: []string{
+			"Get",
+			"http://example.com",
+			"dial",
+			"tcp",
+		},
+	},
+	"WrappedPathError": {
+		error: fmt.Errorf("ctxstd_test: wrapped: %w", &os.PathError{
+			Op:   "open",
+			Path: "/etc/sklog",
+			Err:  errors.New("ctxstd_test: example error"),
+		}),
+		keys: []string{
+			"os_op",
+			"os_path",
+			sklog.KeyErrorChain,
+		},
+		values: []string{
+			"open",
+			"/etc/sklog",
+			"ctxstd_test: wrapped: open /etc/sklog: ctxstd_test: example error",
+		},
+	},
+	"JoinedSyntaxError": {
+		error: errors.Join(errors.New("ctxstd_test: first"), &json.SyntaxError{Offset: 7}),
+		keys: []string{
+			"json_offset",
+			sklog.KeyErrorChain,
+		},
+		values: []string{
+			"7",
+			"ctxstd_test: first",
+		},
+	},
+	"WrappedContexter": {
+		error: fmt.Errorf("ctxstd_test: wrapped: %w", &contexterError{}),
+		keys: []string{
+			"contexter_key",
+			sklog.KeyErrorChain,
+		},
+		values: []string{
+			"contexter_value",
+		},
+	},
+}
+
+type contexterError struct{}
+
+func (ce *contexterError) Error() string {
+	return "ctxstd_test: contexter error"
+}
+
+func (ce *contexterError) Context() []interface{} {
+	return []interface{}{"contexter_key", "contexter_value"}
+}
@@ -0,0 +1,158 @@
+package ctxstd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/sklog"
+)
+
+// Redacted replaces values of sensitive headers.
+const Redacted = "[REDACTED]"
+
+var defaultRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// RoundTripperOption configures http.RoundTripper returned by NewRoundTripper.
+type RoundTripperOption func(*roundTripper)
+
+// WithFailuresOnly makes round tripper log only requests that failed or ended with 5xx status.
+// If combined with WithSlowThreshold, requests that are either failed or slow are logged.
+func WithFailuresOnly() RoundTripperOption {
+	return func(rt *roundTripper) {
+		rt.failuresOnly = true
+	}
+}
+
+// WithSlowThreshold makes round tripper log only requests that took longer than given threshold.
+// If combined with WithFailuresOnly, requests that are either failed or slow are logged.
+func WithSlowThreshold(threshold time.Duration) RoundTripperOption {
+	return func(rt *roundTripper) {
+		rt.slow = threshold
+	}
+}
+
+// WithHeaders makes round tripper log request headers. Values of sensitive headers
+// (Authorization, Proxy-Authorization, Cookie, Set-Cookie and X-Api-Key) are replaced by Redacted.
+func WithHeaders() RoundTripperOption {
+	return func(rt *roundTripper) {
+		rt.headers = true
+	}
+}
+
+// WithRedactedHeaders adds headers to the list of those whose values are redacted.
+func WithRedactedHeaders(names ...string) RoundTripperOption {
+	return func(rt *roundTripper) {
+		for _, name := range names {
+			rt.redacted[http.CanonicalHeaderKey(name)] = struct{}{}
+		}
+	}
+}
+
+type retriesContextKey struct{}
+
+// ContextWithRetries returns copy of given context that carries number of retries made so far.
+// Retry loops built on top of the round tripper can use it to make it log which retry a request was.
+// By default it is 0. The round tripper itself never re-sends requests.
+func ContextWithRetries(ctx context.Context, retries int) context.Context {
+	return context.WithValue(ctx, retriesContextKey{}, retries)
+}
+
+func retriesFromContext(ctx context.Context) int {
+	retries, _ := ctx.Value(retriesContextKey{}).(int)
+	return retries
+}
+
+type roundTripper struct {
+	logger       *sklog.Logger
+	next         http.RoundTripper
+	failuresOnly bool
+	slow         time.Duration
+	headers      bool
+	redacted     map[string]struct{}
+}
+
+// NewRoundTripper returns http.RoundTripper that logs every outgoing request with method, host, path, status,
+// duration and number of retries (see ContextWithRetries). Requests that failed on the transport level are logged as errors,
+// with error passed through context error function of given logger, otherwise level is derived from the status
+// (see sklog.HTTPLevel). To have *url.Error and *net.OpError decoded, the logger should use NewContextErrorGeneric
+// or a sklog.ContextErrorRegistry that ctxstd is registered in.
+// If next is nil, http.DefaultTransport is used.
+func NewRoundTripper(logger *sklog.Logger, next http.RoundTripper, opts ...RoundTripperOption) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	rt := &roundTripper{
+		logger:   logger,
+		next:     next,
+		redacted: make(map[string]struct{}, len(defaultRedactedHeaders)),
+	}
+	for _, name := range defaultRedactedHeaders {
+		rt.redacted[name] = struct{}{}
+	}
+	for _, opt := range opts {
+		opt(rt)
+	}
+
+	return rt
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (rt *roundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := rt.next.RoundTrip(r)
+	elapsed := time.Since(start)
+
+	if !rt.shouldLog(err != nil || res.StatusCode >= http.StatusInternalServerError, elapsed) {
+		return res, err
+	}
+
+	kv := []interface{}{
+		sklog.KeyHTTPMethod, r.Method,
+		sklog.KeyHTTPHost, r.URL.Host,
+		sklog.KeyHTTPPath, r.URL.Path,
+		sklog.KeyHTTPDuration, elapsed,
+		sklog.KeyHTTPRetries, retriesFromContext(r.Context()),
+	}
+	if rt.headers {
+		kv = append(kv, sklog.KeyHTTPHeaders, rt.redact(r.Header))
+	}
+
+	l := rt.logger.With(sklog.KeyvalsFromContext(r.Context())...)
+	if err != nil {
+		l.Error(err, kv...)
+		return res, err
+	}
+	l.LogLevel(sklog.HTTPLevel(res.StatusCode), "request sent", append(kv, sklog.KeyHTTPStatus, res.StatusCode)...)
+
+	return res, err
+}
+
+func (rt *roundTripper) shouldLog(failed bool, elapsed time.Duration) bool {
+	if !rt.failuresOnly && rt.slow <= 0 {
+		return true
+	}
+
+	return (rt.failuresOnly && failed) || (rt.slow > 0 && elapsed > rt.slow)
+}
+
+func (rt *roundTripper) redact(header http.Header) map[string]string {
+	res := make(map[string]string, len(header))
+	for name, values := range header {
+		if _, ok := rt.redacted[name]; ok {
+			res[name] = Redacted
+			continue
+		}
+		res[name] = strings.Join(values, ",")
+	}
+
+	return res
+}
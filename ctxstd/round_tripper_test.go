@@ -0,0 +1,189 @@
+package ctxstd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/piotrkowalczuk/sklog/ctxjson"
+	"github.com/piotrkowalczuk/sklog/ctxstd"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
+
+func TestNewRoundTripper(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	b := bytes.NewBuffer(nil)
+	client := &http.Client{Transport: ctxstd.NewRoundTripper(sklog.NewLogger(log.NewJSONLogger(b)), nil, ctxstd.WithHeaders(), ctxstd.WithRedactedHeaders("x-secret"))}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/users", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Secret", "secret")
+	req.Header.Set("Accept", "application/json")
+	res, err := client.Do(req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	res.Body.Close()
+
+	assert.Contains(t, b.String(), `"level":"warn"`)
+	assert.Contains(t, b.String(), `"http_method":"GET"`)
+	assert.Contains(t, b.String(), `"http_host":"`+strings.TrimPrefix(srv.URL, "http://")+`"`)
+	assert.Contains(t, b.String(), `"http_path":"/users"`)
+	assert.Contains(t, b.String(), `"http_status":404`)
+	assert.Contains(t, b.String(), `"http_retries":0`)
+	assert.Contains(t, b.String(), `"http_duration"`)
+	assert.Contains(t, b.String(), `"Authorization":"[REDACTED]"`)
+	assert.Contains(t, b.String(), `"X-Secret":"[REDACTED]"`)
+	assert.Contains(t, b.String(), `"Accept":"application/json"`)
+	assert.NotContains(t, b.String(), "Bearer")
+}
+
+func TestNewRoundTripper_error(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	next := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	})
+	client := &http.Client{Transport: ctxstd.NewRoundTripper(sklog.NewLogger(log.NewJSONLogger(b), sklog.WithContextErrorFunc(ctxstd.NewContextErrorGeneric)), next)}
+
+	_, err := client.Get("http://example.com/users")
+
+	assert.Error(t, err)
+	assert.Contains(t, b.String(), `"level":"error"`)
+	assert.Contains(t, b.String(), `"net_op":"dial"`)
+	assert.Contains(t, b.String(), `"http_host":"example.com"`)
+}
+
+func TestNewRoundTripper_contextErrorFunc(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	next := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection reset")
+	})
+	logger := sklog.NewLogger(log.NewJSONLogger(b), sklog.WithContextErrorFunc(func(l log.Logger, err error) *log.Context {
+		return log.NewContext(l).With("custom", err.Error())
+	}))
+	client := &http.Client{Transport: ctxstd.NewRoundTripper(logger, next)}
+
+	_, err := client.Get("http://example.com/users")
+
+	assert.Error(t, err)
+	assert.Contains(t, b.String(), `"custom":"connection reset"`)
+}
+
+func TestNewRoundTripper_dialError(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	b := bytes.NewBuffer(nil)
+	r := sklog.NewContextErrorRegistry()
+	ctxjson.Register(r)
+	ctxstd.Register(r)
+	client := &http.Client{Transport: ctxstd.NewRoundTripper(sklog.NewLogger(log.NewJSONLogger(b), sklog.WithContextErrorFunc(r.NewContextError)), nil)}
+
+	_, err = client.Get("http://" + addr + "/users")
+
+	assert.Error(t, err)
+	assert.Contains(t, b.String(), `"level":"error"`)
+	assert.Contains(t, b.String(), `"net_op":"dial"`)
+	assert.Contains(t, b.String(), `"net_net":"tcp"`)
+	assert.Contains(t, b.String(), `"http_host":"`+addr+`"`)
+}
+
+func TestNewRoundTripper_urlError(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	next := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, &url.Error{
+			Op:  "Get",
+			URL: r.URL.String(),
+			Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+		}
+	})
+	client := &http.Client{Transport: ctxstd.NewRoundTripper(sklog.NewLogger(log.NewJSONLogger(b), sklog.WithContextErrorFunc(ctxstd.NewContextErrorGeneric)), next)}
+
+	_, err := client.Get("http://example.com/users")
+
+	assert.Error(t, err)
+	assert.Contains(t, b.String(), `"url_op":"Get"`)
+	assert.Contains(t, b.String(), `"url_url":"http://example.com/users"`)
+	assert.Contains(t, b.String(), `"net_op":"dial"`)
+	assert.Contains(t, b.String(), `"net_net":"tcp"`)
+}
+
+func TestNewRoundTripper_retries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	var attempts int
+	b := bytes.NewBuffer(nil)
+	client := &http.Client{Transport: ctxstd.NewRoundTripper(sklog.NewLogger(log.NewJSONLogger(b)), roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		return http.DefaultTransport.RoundTrip(r)
+	}))}
+
+	req, _ := http.NewRequestWithContext(ctxstd.ContextWithRetries(context.Background(), 2), http.MethodPut, srv.URL, strings.NewReader("body"))
+	res, err := client.Do(req)
+	if assert.NoError(t, err) {
+		res.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.Equal(t, 1, attempts)
+		assert.Contains(t, b.String(), `"http_retries":2`)
+	}
+}
+
+func TestNewRoundTripper_filter(t *testing.T) {
+	ok := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	failed := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}, nil
+	})
+
+	cases := map[string]struct {
+		next   http.RoundTripper
+		opts   []ctxstd.RoundTripperOption
+		logged bool
+	}{
+		"failures-only-success": {next: ok, opts: []ctxstd.RoundTripperOption{ctxstd.WithFailuresOnly()}},
+		"failures-only-failure": {next: failed, opts: []ctxstd.RoundTripperOption{ctxstd.WithFailuresOnly()}, logged: true},
+		"slow-fast":             {next: ok, opts: []ctxstd.RoundTripperOption{ctxstd.WithSlowThreshold(time.Hour)}},
+		"none":                  {next: ok, logged: true},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			rt := ctxstd.NewRoundTripper(sklog.NewLogger(log.NewJSONLogger(b)), c.next, c.opts...)
+
+			_, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "http://example.com", nil))
+
+			assert.NoError(t, err)
+			assert.Equal(t, c.logged, b.Len() > 0)
+		})
+	}
+}
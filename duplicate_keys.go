@@ -0,0 +1,69 @@
+package sklog
+
+import (
+	"fmt"
+)
+
+// DuplicatePolicy defines how humane and test loggers handle key that occurs more than once in a single record.
+type DuplicatePolicy int
+
+const (
+	// DuplicateKeepLast keeps the last value, it is the default.
+	DuplicateKeepLast DuplicatePolicy = iota
+	// DuplicateKeepFirst keeps the first value.
+	DuplicateKeepFirst
+	// DuplicateRename keeps all values, duplicates are renamed by adding a suffix (msg, msg_1, msg_2).
+	DuplicateRename
+	// DuplicateCollect collects all values into a list under the original key.
+	DuplicateCollect
+)
+
+// reservedKeys are keys added by sklog itself, callers should not pass them.
+var reservedKeys = map[string]struct{}{
+	KeyLevel:      {},
+	KeyTimestamp:  {},
+	KeyMessage:    {},
+	KeySubsystem:  {},
+	KeyCaller:     {},
+	KeyFunction:   {},
+	KeyStackTrace: {},
+	KeyErrorChain: {},
+}
+
+// collected holds values of a duplicated key, see DuplicateCollect.
+type collected []interface{}
+
+// WithDuplicatePolicy sets how keys that occur more than once in a single record are handled.
+func WithDuplicatePolicy(policy DuplicatePolicy) HumaneOption {
+	return func(hc *humaneConfig) {
+		hc.duplicates = policy
+	}
+}
+
+// WithReservedKeyWarnings makes logger print a warning each time a reserved key (like level, timestamp or msg)
+// occurs more than once in a single record, which usually means that a caller passed a key that sklog adds itself.
+// It is meant for development.
+func WithReservedKeyWarnings() HumaneOption {
+	return func(hc *humaneConfig) {
+		hc.reservedKeyLog = true
+	}
+}
+
+func reservedKeyWarnings(duplicates []string) []string {
+	var (
+		res  []string
+		seen = make(map[string]struct{}, len(duplicates))
+	)
+	for _, key := range duplicates {
+		if _, ok := reservedKeys[key]; !ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		res = append(res, fmt.Sprintf("sklog: reserved key %q occurred more than once", key))
+	}
+
+	return res
+}
@@ -0,0 +1,88 @@
+package sklog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDuplicatePolicy(t *testing.T) {
+	keyvals := []interface{}{KeyMessage, "first", "a", 1, KeyMessage, "second", KeyMessage, "third"}
+
+	cases := map[string]struct {
+		policy   DuplicatePolicy
+		expected string
+	}{
+		"keep-last": {
+			policy:   DuplicateKeepLast,
+			expected: "msg=third  a=1  \n",
+		},
+		"keep-first": {
+			policy:   DuplicateKeepFirst,
+			expected: "msg=first  a=1  \n",
+		},
+		"rename": {
+			policy:   DuplicateRename,
+			expected: "msg=first  a=1  msg_1=second  msg_2=third  \n",
+		},
+		"collect": {
+			policy:   DuplicateCollect,
+			expected: `msg=["first","second","third"]  a=1  ` + "\n",
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			l := NewHumaneLogger(b, NewSequentialFormatter(), WithDuplicatePolicy(c.policy))
+
+			if assert.NoError(t, l.Log(keyvals...)) {
+				assert.Equal(t, c.expected, b.String())
+			}
+		})
+	}
+}
+
+func TestWithDuplicatePolicy_testLogger(t *testing.T) {
+	got, duplicates := NewTestLogger(t, WithDuplicatePolicy(DuplicateRename)).(*testLogger).format([]interface{}{
+		KeyMessage, "first", KeyMessage, "second",
+	})
+
+	assert.Equal(t, "first"+spaces(55)+"msg_1=second  ", got)
+	assert.Equal(t, []string{KeyMessage}, duplicates)
+}
+
+func TestWithReservedKeyWarnings(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewLogger(
+		NewHumaneLogger(b, NewSequentialFormatter(NewKeyFormatter("%v ", KeyMessage)), WithReservedKeyWarnings()),
+		WithTimestampFunc(func() string { return "now" }),
+	)
+
+	l.Info("log message", KeyLevel, "custom", "a", 1, "a", 2)
+
+	lines := bytes.Split(bytes.TrimSpace(b.Bytes()), []byte("\n"))
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, `sklog: reserved key "level" occurred more than once`, string(lines[1]))
+	}
+}
+
+func TestWithReservedKeyWarnings_error(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewLogger(
+		NewHumaneLogger(b, NewSequentialFormatter(), WithReservedKeyWarnings(), WithDuplicatePolicy(DuplicateCollect)),
+		WithTimestampFunc(func() string { return "now" }),
+		WithStackTraceDepth(0),
+	)
+
+	l.Error(fmt.Errorf("wrapped: %w", errors.New("boom")))
+
+	lines := bytes.Split(bytes.TrimSpace(b.Bytes()), []byte("\n"))
+	if assert.Len(t, lines, 1) {
+		assert.Contains(t, string(lines[0]), "msg=wrapped: boom  ")
+		assert.Equal(t, 1, bytes.Count(lines[0], []byte("error_chain=")))
+	}
+}
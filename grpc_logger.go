@@ -1,13 +1,18 @@
 package sklog
 
 import (
-	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/go-kit/kit/log"
 )
 
-// GRPCLogger ...
+// SubsystemGRPC is a subsystem that records produced by GRPCLogger are tagged with.
+const SubsystemGRPC = "grpc"
+
+// GRPCLogger is compatible with grpclog.Logger, grpclog.LoggerV2 and grpclog.DepthLoggerV2 interfaces.
 type GRPCLogger interface {
 	Fatal(args ...interface{})
 	Fatalf(format string, args ...interface{})
@@ -15,25 +20,62 @@ type GRPCLogger interface {
 	Print(args ...interface{})
 	Printf(format string, args ...interface{})
 	Println(args ...interface{})
+
+	Info(args ...interface{})
+	Infof(format string, args ...interface{})
+	Infoln(args ...interface{})
+	Warning(args ...interface{})
+	Warningf(format string, args ...interface{})
+	Warningln(args ...interface{})
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Errorln(args ...interface{})
+	V(level int) bool
+
+	InfoDepth(depth int, args ...interface{})
+	WarningDepth(depth int, args ...interface{})
+	ErrorDepth(depth int, args ...interface{})
+	FatalDepth(depth int, args ...interface{})
+}
+
+// GRPCLoggerOption configures logger allocated by NewGRPCLogger.
+type GRPCLoggerOption func(*gRPCLogger)
+
+// GRPCVerbosity sets verbosity level reported by V method.
+// By default it is taken from GRPC_GO_LOG_VERBOSITY_LEVEL environment variable.
+func GRPCVerbosity(level int) GRPCLoggerOption {
+	return func(grl *gRPCLogger) {
+		grl.verbosity = level
+	}
 }
 
 type gRPCLogger struct {
 	log.Logger
+	verbosity int
 }
 
-// NewGRPCLogger ...
-func NewGRPCLogger(logger log.Logger) GRPCLogger {
-	return &gRPCLogger{
+// NewGRPCLogger allocates logger that can be used by grpclog package (grpclog.SetLoggerV2).
+// Records are logged with corresponding level and subsystem set to SubsystemGRPC.
+func NewGRPCLogger(logger log.Logger, opts ...GRPCLoggerOption) GRPCLogger {
+	grl := &gRPCLogger{
 		Logger: logger,
 	}
+	if v, err := strconv.Atoi(os.Getenv("GRPC_GO_LOG_VERBOSITY_LEVEL")); err == nil {
+		grl.verbosity = v
+	}
+	for _, opt := range opts {
+		opt(grl)
+	}
+
+	return grl
 }
 
 func (grl *gRPCLogger) Fatal(args ...interface{}) {
-	Fatal(grl.Logger, errors.New(fmt.Sprint(args...)))
+	grl.fatal(0, fmt.Sprint(args...))
 }
 
 func (grl *gRPCLogger) Fatalf(format string, args ...interface{}) {
-	Fatal(grl.Logger, fmt.Errorf(format, args...))
+	grl.fatal(0, fmt.Sprintf(format, args...))
 }
 
 func (grl *gRPCLogger) Fatalln(args ...interface{}) {
@@ -49,13 +91,90 @@ func (grl *gRPCLogger) Print(args ...interface{}) {
 		message += fmt.Sprint(arg)
 	}
 
-	Debug(grl.Logger, message)
+	grl.log(0, LevelDebug, message)
 }
 
 func (grl *gRPCLogger) Printf(format string, args ...interface{}) {
-	Debug(grl.Logger, fmt.Sprintf(format, args...))
+	grl.log(0, LevelDebug, fmt.Sprintf(format, args...))
 }
 
 func (grl *gRPCLogger) Println(args ...interface{}) {
 	grl.Print(args...)
 }
+
+func (grl *gRPCLogger) Info(args ...interface{}) {
+	grl.log(0, LevelInfo, fmt.Sprint(args...))
+}
+
+func (grl *gRPCLogger) Infof(format string, args ...interface{}) {
+	grl.log(0, LevelInfo, fmt.Sprintf(format, args...))
+}
+
+func (grl *gRPCLogger) Infoln(args ...interface{}) {
+	grl.log(0, LevelInfo, sprintln(args...))
+}
+
+func (grl *gRPCLogger) Warning(args ...interface{}) {
+	grl.log(0, LevelWarning, fmt.Sprint(args...))
+}
+
+func (grl *gRPCLogger) Warningf(format string, args ...interface{}) {
+	grl.log(0, LevelWarning, fmt.Sprintf(format, args...))
+}
+
+func (grl *gRPCLogger) Warningln(args ...interface{}) {
+	grl.log(0, LevelWarning, sprintln(args...))
+}
+
+func (grl *gRPCLogger) Error(args ...interface{}) {
+	grl.error(0, fmt.Sprint(args...))
+}
+
+func (grl *gRPCLogger) Errorf(format string, args ...interface{}) {
+	grl.error(0, fmt.Sprintf(format, args...))
+}
+
+func (grl *gRPCLogger) Errorln(args ...interface{}) {
+	grl.error(0, sprintln(args...))
+}
+
+// V reports whether verbosity level l is at least the requested verbose level.
+func (grl *gRPCLogger) V(level int) bool {
+	return level <= grl.verbosity
+}
+
+func (grl *gRPCLogger) InfoDepth(depth int, args ...interface{}) {
+	grl.log(depth, LevelInfo, sprintln(args...))
+}
+
+func (grl *gRPCLogger) WarningDepth(depth int, args ...interface{}) {
+	grl.log(depth, LevelWarning, sprintln(args...))
+}
+
+func (grl *gRPCLogger) ErrorDepth(depth int, args ...interface{}) {
+	grl.error(depth, sprintln(args...))
+}
+
+func (grl *gRPCLogger) FatalDepth(depth int, args ...interface{}) {
+	grl.fatal(depth, sprintln(args...))
+}
+
+func (grl *gRPCLogger) log(depth int, level, msg string) {
+	grl.Logger.Log(std().recordDepth(depth, []interface{}{KeySubsystem, SubsystemGRPC}, KeyLevel, level, KeyMessage, msg)...)
+}
+
+// error logs plain message, errors reported by gRPC are not passed through the context error function
+// and carry no stack trace, it would point at gRPC internals anyway.
+func (grl *gRPCLogger) error(depth int, msg string) {
+	grl.log(depth, LevelError, msg)
+}
+
+func (grl *gRPCLogger) fatal(depth int, msg string) {
+	grl.log(depth, LevelFatal, msg)
+	os.Exit(1)
+}
+
+// sprintln formats arguments in the manner of fmt.Println, without trailing new line.
+func sprintln(args ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
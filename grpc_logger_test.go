@@ -7,8 +7,11 @@ import (
 	"github.com/go-kit/kit/log"
 	"github.com/piotrkowalczuk/sklog"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/grpclog"
 )
 
+var _ grpclog.DepthLoggerV2 = sklog.NewGRPCLogger(nil)
+
 func TestGRPCLogger_Print(t *testing.T) {
 	b := bytes.NewBuffer(nil)
 	l := log.NewJSONLogger(b)
@@ -73,3 +76,63 @@ func TestGRPCLogger_Printf(t *testing.T) {
 		b.Reset()
 	}
 }
+
+func TestGRPCLogger_levels(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	g := sklog.NewGRPCLogger(log.NewJSONLogger(b))
+
+	success := []struct {
+		level string
+		log   func()
+	}{
+		{level: sklog.LevelInfo, log: func() { g.Info("message") }},
+		{level: sklog.LevelInfo, log: func() { g.Infof("%s", "message") }},
+		{level: sklog.LevelInfo, log: func() { g.Infoln("message") }},
+		{level: sklog.LevelInfo, log: func() { g.InfoDepth(0, "message") }},
+		{level: sklog.LevelWarning, log: func() { g.Warning("message") }},
+		{level: sklog.LevelWarning, log: func() { g.Warningf("%s", "message") }},
+		{level: sklog.LevelWarning, log: func() { g.Warningln("message") }},
+		{level: sklog.LevelWarning, log: func() { g.WarningDepth(0, "message") }},
+		{level: sklog.LevelError, log: func() { g.Error("message") }},
+		{level: sklog.LevelError, log: func() { g.Errorf("%s", "message") }},
+		{level: sklog.LevelError, log: func() { g.Errorln("message") }},
+		{level: sklog.LevelError, log: func() { g.ErrorDepth(0, "message") }},
+	}
+
+	for _, data := range success {
+		data.log()
+		got := decode(t, b)
+		assert.Equal(t, data.level, got[sklog.KeyLevel])
+		assert.Equal(t, "message", got[sklog.KeyMessage])
+		assert.Equal(t, sklog.SubsystemGRPC, got[sklog.KeySubsystem])
+		assert.NotContains(t, got, sklog.KeyStackTrace)
+	}
+}
+
+func TestGRPCLogger_V(t *testing.T) {
+	g := sklog.NewGRPCLogger(log.NewNopLogger(), sklog.GRPCVerbosity(2))
+
+	assert.True(t, g.V(0))
+	assert.True(t, g.V(2))
+	assert.False(t, g.V(3))
+}
+
+func TestGRPCLogger_InfoDepth(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	g := sklog.NewGRPCLogger(log.NewJSONLogger(b))
+
+	sklog.SetCallerMode(sklog.CallerFile)
+	defer sklog.SetCallerMode(sklog.CallerOff)
+
+	func() {
+		g.InfoDepth(1, "message")
+	}()
+	exp := previousLine()
+	assert.Equal(t, exp, decode(t, b)[sklog.KeyCaller])
+
+	func() {
+		g.ErrorDepth(1, "message")
+	}()
+	exp = previousLine()
+	assert.Equal(t, exp, decode(t, b)[sklog.KeyCaller])
+}
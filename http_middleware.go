@@ -0,0 +1,317 @@
+package sklog
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// HeaderRequestID is a header that is expected to carry request id.
+const HeaderRequestID = "X-Request-Id"
+
+type contextHandler struct {
+	logger  *Logger
+	handler http.Handler
+}
+
+// NewContextHandler returns http.Handler that stores given logger in the request context,
+// together with request id (taken from X-Request-Id header), method and path.
+// Handlers down the chain can retrieve it using FromContext.
+// Request fields added by another sklog middleware up the chain are not added again.
+func NewContextHandler(logger *Logger, handler http.Handler) http.Handler {
+	return &contextHandler{
+		logger:  logger,
+		handler: handler,
+	}
+}
+
+// ServeHTTP implements http.Handler interface.
+func (ch *contextHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ch.handler.ServeHTTP(rw, r.WithContext(newRequestContext(ch.logger, r)))
+}
+
+// requestContextKey marks a context that carries request key/value pairs already.
+type requestContextKey struct{}
+
+// newRequestContext stores given logger in the request context. Request id, method and path are added
+// unless a middleware up the chain did it already.
+func newRequestContext(logger *Logger, r *http.Request) context.Context {
+	ctx := ContextWithLogger(r.Context(), logger)
+	if ctx.Value(requestContextKey{}) != nil {
+		return ctx
+	}
+	if id := r.Header.Get(HeaderRequestID); id != "" {
+		ctx = ContextWithKeyvals(ctx, KeyRequestID, id)
+	}
+	ctx = ContextWithKeyvals(ctx, KeyHTTPMethod, r.Method, KeyHTTPPath, r.URL.Path)
+
+	return context.WithValue(ctx, requestContextKey{}, true)
+}
+
+type accessLogHandler struct {
+	logger  *Logger
+	handler http.Handler
+}
+
+// NewAccessLogHandler returns http.Handler that logs every request once it is served.
+// Records carry method, path, request URI, protocol, status, number of bytes written, duration, remote address, user agent,
+// referer and request id.
+// Requests that ended with 5xx status are logged as errors, 4xx as warnings and the rest as info.
+// Like NewContextHandler, it stores logger in the request context. If NewContextHandler (or another sklog middleware)
+// prepared the request context already, that context is reused as is, together with its logger.
+func NewAccessLogHandler(logger *Logger, handler http.Handler) http.Handler {
+	return &accessLogHandler{
+		logger:  logger,
+		handler: handler,
+	}
+}
+
+// ServeHTTP implements http.Handler interface.
+func (alh *accessLogHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	if ctx.Value(requestContextKey{}) == nil {
+		ctx = newRequestContext(alh.logger, r)
+	}
+	w, wrapped := newResponseWriter(rw)
+
+	alh.handler.ServeHTTP(wrapped, r.WithContext(ctx))
+
+	status := w.Status()
+	l := FromContext(ctx)
+	l.log(l.logger, HTTPLevel(status), "request processed", []interface{}{
+		KeyHTTPStatus, status,
+		KeyHTTPBytes, w.bytes,
+		KeyHTTPDuration, time.Since(start),
+		KeyHTTPRemoteAddr, r.RemoteAddr,
+		KeyHTTPUserAgent, r.UserAgent(),
+		KeyHTTPProto, r.Proto,
+		KeyHTTPReferer, r.Referer(),
+		KeyHTTPRequestURI, requestURI(r),
+	})
+}
+
+// requestURI returns request target as sent by the client, requests created by hand may lack it.
+func requestURI(r *http.Request) string {
+	if r.RequestURI != "" {
+		return r.RequestURI
+	}
+
+	return r.URL.RequestURI()
+}
+
+// HTTPLevel returns level that a request that ended with given status should be logged with.
+func HTTPLevel(status int) string {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return LevelError
+	case status >= http.StatusBadRequest:
+		return LevelWarning
+	default:
+		return LevelInfo
+	}
+}
+
+// responseWriter records status and number of bytes written.
+// It can be unwrapped by http.ResponseController. Optional interfaces are added by newResponseWriter.
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+// newResponseWriter wraps given writer. Returned http.ResponseWriter implements http.Flusher, http.Hijacker
+// and http.Pusher only if given writer does, so that type assertions keep reporting actual capabilities.
+// If given writer was returned by newResponseWriter already, it is reused.
+func newResponseWriter(rw http.ResponseWriter) (*responseWriter, http.ResponseWriter) {
+	if r, ok := rw.(interface{ recorder() *responseWriter }); ok {
+		return r.recorder(), rw
+	}
+
+	w := &responseWriter{ResponseWriter: rw}
+	_, isFlusher := rw.(http.Flusher)
+	_, isHijacker := rw.(http.Hijacker)
+	_, isPusher := rw.(http.Pusher)
+
+	switch {
+	case isFlusher && isHijacker && isPusher:
+		return w, struct {
+			*responseWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{w, flusher{w}, hijacker{w}, pusher{w}}
+	case isFlusher && isHijacker:
+		return w, struct {
+			*responseWriter
+			http.Flusher
+			http.Hijacker
+		}{w, flusher{w}, hijacker{w}}
+	case isFlusher && isPusher:
+		return w, struct {
+			*responseWriter
+			http.Flusher
+			http.Pusher
+		}{w, flusher{w}, pusher{w}}
+	case isHijacker && isPusher:
+		return w, struct {
+			*responseWriter
+			http.Hijacker
+			http.Pusher
+		}{w, hijacker{w}, pusher{w}}
+	case isFlusher:
+		return w, struct {
+			*responseWriter
+			http.Flusher
+		}{w, flusher{w}}
+	case isHijacker:
+		return w, struct {
+			*responseWriter
+			http.Hijacker
+		}{w, hijacker{w}}
+	case isPusher:
+		return w, struct {
+			*responseWriter
+			http.Pusher
+		}{w, pusher{w}}
+	default:
+		return w, w
+	}
+}
+
+func (w *responseWriter) recorder() *responseWriter {
+	return w
+}
+
+// Status returns status written so far, 200 if handler wrote body without explicit WriteHeader call.
+func (w *responseWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+// WriteHeader implements http.ResponseWriter interface.
+// Informational statuses (1xx, other than 101 Switching Protocols) are passed through, but not recorded.
+func (w *responseWriter) WriteHeader(status int) {
+	informational := status >= 100 && status < 200 && status != http.StatusSwitchingProtocols
+	if w.status == 0 && !informational {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter interface.
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+
+	return n, err
+}
+
+// Unwrap returns wrapped http.ResponseWriter, it is used by http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+type flusher struct {
+	w *responseWriter
+}
+
+// Flush implements http.Flusher interface.
+func (f flusher) Flush() {
+	if f.w.status == 0 {
+		f.w.status = http.StatusOK
+	}
+	f.w.ResponseWriter.(http.Flusher).Flush()
+}
+
+type hijacker struct {
+	w *responseWriter
+}
+
+// Hijack implements http.Hijacker interface.
+func (h hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := h.w.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil && h.w.status == 0 {
+		h.w.status = http.StatusSwitchingProtocols
+	}
+
+	return conn, rw, err
+}
+
+type pusher struct {
+	w *responseWriter
+}
+
+// Push implements http.Pusher interface.
+func (p pusher) Push(target string, opts *http.PushOptions) error {
+	return p.w.ResponseWriter.(http.Pusher).Push(target, opts)
+}
+
+// RecoveryOption configures handler returned by NewRecoveryHandler.
+type RecoveryOption func(*recoveryHandler)
+
+// RepanicOnAbort makes recovery handler panic again with http.ErrAbortHandler, without logging it,
+// so the server can abort the response as intended.
+func RepanicOnAbort() RecoveryOption {
+	return func(rh *recoveryHandler) {
+		rh.repanicOnAbort = true
+	}
+}
+
+type recoveryHandler struct {
+	logger         *Logger
+	handler        http.Handler
+	repanicOnAbort bool
+}
+
+// NewRecoveryHandler returns http.Handler that recovers from panics that occur in given handler.
+// Recovered value is logged with level panic (see Logger.Recovered) together with stack trace,
+// request id, method and path, and the client receives 500 Internal Server Error (if nothing was written yet).
+// To have such requests in access log, it should be wrapped by NewAccessLogHandler.
+// Logger and fields already stored in the request context are reused, given logger is used only if there is none.
+func NewRecoveryHandler(logger *Logger, handler http.Handler, opts ...RecoveryOption) http.Handler {
+	rh := &recoveryHandler{
+		logger:  logger,
+		handler: handler,
+	}
+	for _, opt := range opts {
+		opt(rh)
+	}
+
+	return rh
+}
+
+// ServeHTTP implements http.Handler interface.
+func (rh *recoveryHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	logger, ok := LoggerFromContext(r.Context())
+	if !ok {
+		logger = rh.logger
+	}
+	ctx := newRequestContext(logger, r)
+	w, wrapped := newResponseWriter(rw)
+
+	defer func() {
+		v := recover()
+		if v == nil {
+			return
+		}
+		if v == http.ErrAbortHandler && rh.repanicOnAbort {
+			panic(v)
+		}
+
+		FromContext(ctx).Recovered(v)
+		if w.status == 0 {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	}()
+
+	rh.handler.ServeHTTP(wrapped, r.WithContext(ctx))
+}
@@ -0,0 +1,261 @@
+package sklog_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContextHandler(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	h := sklog.NewContextHandler(sklog.NewLogger(log.NewJSONLogger(b)), http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		sklog.InfoCtx(r.Context(), "handled")
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.Header.Set(sklog.HeaderRequestID, "abc")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Contains(t, b.String(), `"msg":"handled"`)
+	assert.Contains(t, b.String(), `"request_id":"abc"`)
+	assert.Contains(t, b.String(), `"http_method":"GET"`)
+	assert.Contains(t, b.String(), `"http_path":"/users"`)
+}
+
+func TestNewContextHandler_stacked(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLogger(log.NewLogfmtLogger(b))
+	h := sklog.NewContextHandler(l, sklog.NewContextHandler(l, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		sklog.InfoCtx(r.Context(), "handled")
+	})))
+
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.Header.Set(sklog.HeaderRequestID, "abc")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Contains(t, b.String(), "msg=handled")
+	assert.Equal(t, 1, strings.Count(b.String(), "request_id=abc"))
+	assert.Equal(t, 1, strings.Count(b.String(), "http_method=GET"))
+	assert.Equal(t, 1, strings.Count(b.String(), "http_path=/users"))
+}
+
+func TestNewAccessLogHandler(t *testing.T) {
+	cases := map[string]struct {
+		status   int
+		expected string
+	}{
+		"ok":           {status: http.StatusOK, expected: `"level":"info"`},
+		"not-found":    {status: http.StatusNotFound, expected: `"level":"warn"`},
+		"server-error": {status: http.StatusBadGateway, expected: `"level":"error"`},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			h := sklog.NewAccessLogHandler(sklog.NewLogger(log.NewJSONLogger(b)), http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				rw.WriteHeader(c.status)
+				rw.Write([]byte("hello"))
+			}))
+
+			r := httptest.NewRequest(http.MethodPost, "/users", nil)
+			r.Header.Set(sklog.HeaderRequestID, "abc")
+			r.Header.Set("User-Agent", "test")
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.Contains(t, b.String(), c.expected)
+			assert.Contains(t, b.String(), fmt.Sprintf(`"http_status":%d`, c.status))
+			assert.Contains(t, b.String(), `"http_bytes":5`)
+			assert.Contains(t, b.String(), `"http_method":"POST"`)
+			assert.Contains(t, b.String(), `"http_path":"/users"`)
+			assert.Contains(t, b.String(), `"http_remote_addr":"192.0.2.1:1234"`)
+			assert.Contains(t, b.String(), `"http_user_agent":"test"`)
+			assert.Contains(t, b.String(), `"request_id":"abc"`)
+			assert.Contains(t, b.String(), `"http_duration"`)
+		})
+	}
+}
+
+func TestNewAccessLogHandler_contextHandler(t *testing.T) {
+	outer, inner := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	h := sklog.NewContextHandler(sklog.NewLogger(log.NewLogfmtLogger(outer)), sklog.NewAccessLogHandler(sklog.NewLogger(log.NewLogfmtLogger(inner)), http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		sklog.InfoCtx(r.Context(), "handled")
+	})))
+
+	r := httptest.NewRequest(http.MethodGet, "/x", nil)
+	r.Header.Set(sklog.HeaderRequestID, "abc")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Empty(t, inner.String())
+	lines := strings.Split(strings.TrimSpace(outer.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Contains(t, lines[0], "msg=handled")
+		assert.Contains(t, lines[1], `msg="request processed"`)
+		for _, line := range lines {
+			assert.Equal(t, 1, strings.Count(line, "request_id=abc"), line)
+			assert.Equal(t, 1, strings.Count(line, "http_method=GET"), line)
+			assert.Equal(t, 1, strings.Count(line, "http_path=/x"), line)
+		}
+	}
+}
+
+func TestNewAccessLogHandler_interfaces(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	h := sklog.NewAccessLogHandler(sklog.NewLogger(log.NewJSONLogger(b)), http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		f, ok := rw.(http.Flusher)
+		if assert.True(t, ok) {
+			f.Flush()
+		}
+		_, ok = rw.(http.Hijacker)
+		assert.False(t, ok)
+		_, ok = rw.(http.Pusher)
+		assert.False(t, ok)
+		assert.NoError(t, http.NewResponseController(rw).Flush())
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.True(t, rec.Flushed)
+	assert.Contains(t, b.String(), `"http_status":200`)
+}
+
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (hr hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errors.New("sklog_test: hijack failed")
+}
+
+func TestNewAccessLogHandler_hijackFailed(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	h := sklog.NewAccessLogHandler(sklog.NewLogger(log.NewJSONLogger(b)), http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if hj, ok := rw.(http.Hijacker); assert.True(t, ok) {
+			_, _, err := hj.Hijack()
+			assert.Error(t, err)
+		}
+		_, ok := rw.(http.Pusher)
+		assert.False(t, ok)
+		rw.WriteHeader(http.StatusBadRequest)
+	}))
+
+	h.ServeHTTP(hijackRecorder{httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Contains(t, b.String(), `"http_status":400`)
+}
+
+func TestNewAccessLogHandler_informational(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	h := sklog.NewAccessLogHandler(sklog.NewLogger(log.NewJSONLogger(b)), http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusEarlyHints)
+		rw.WriteHeader(http.StatusCreated)
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Contains(t, b.String(), `"http_status":201`)
+}
+
+func TestHTTPLevel(t *testing.T) {
+	assert.Equal(t, sklog.LevelInfo, sklog.HTTPLevel(http.StatusNoContent))
+	assert.Equal(t, sklog.LevelInfo, sklog.HTTPLevel(http.StatusFound))
+	assert.Equal(t, sklog.LevelWarning, sklog.HTTPLevel(http.StatusUnauthorized))
+	assert.Equal(t, sklog.LevelError, sklog.HTTPLevel(http.StatusServiceUnavailable))
+}
+
+func TestNewRecoveryHandler(t *testing.T) {
+	cases := map[string]struct {
+		value    interface{}
+		expected string
+	}{
+		"error":  {value: errors.New("nil map"), expected: `"msg":"nil map"`},
+		"string": {value: "out of range", expected: `"msg":"out of range"`},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			h := sklog.NewRecoveryHandler(sklog.NewLogger(log.NewJSONLogger(b)), http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				panic(c.value)
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/users", nil)
+			r.Header.Set(sklog.HeaderRequestID, "abc")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+
+			assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			assert.Contains(t, b.String(), `"level":"panic"`)
+			assert.Contains(t, b.String(), c.expected)
+			assert.Contains(t, b.String(), `"request_id":"abc"`)
+			assert.Contains(t, b.String(), `"http_path":"/users"`)
+			assert.Contains(t, b.String(), "TestNewRecoveryHandler")
+		})
+	}
+}
+
+func TestNewRecoveryHandler_abort(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	h := sklog.NewRecoveryHandler(sklog.NewLogger(log.NewJSONLogger(b)), handler, sklog.RepanicOnAbort())
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.Empty(t, b.String())
+
+	h = sklog.NewRecoveryHandler(sklog.NewLogger(log.NewJSONLogger(b)), handler)
+	assert.NotPanics(t, func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.Contains(t, b.String(), `"level":"panic"`)
+}
+
+func TestNewRecoveryHandler_accessLog(t *testing.T) {
+	outer, inner := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	h := sklog.NewAccessLogHandler(sklog.NewLogger(log.NewLogfmtLogger(outer)), sklog.NewRecoveryHandler(sklog.NewLogger(log.NewLogfmtLogger(inner)), http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		sklog.InfoCtx(r.Context(), "handled")
+		panic("out of range")
+	})))
+
+	r := httptest.NewRequest(http.MethodGet, "/x", nil)
+	r.Header.Set(sklog.HeaderRequestID, "abc")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Empty(t, inner.String())
+	lines := strings.Split(strings.TrimSpace(outer.String()), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.Contains(t, lines[0], "msg=handled")
+		assert.Contains(t, lines[1], "level=panic")
+		assert.Contains(t, lines[2], "http_status=500")
+		for _, line := range lines {
+			assert.Equal(t, 1, strings.Count(line, "request_id=abc"), line)
+			assert.Equal(t, 1, strings.Count(line, "http_method=GET"), line)
+			assert.Equal(t, 1, strings.Count(line, "http_path=/x"), line)
+		}
+	}
+}
+
+func TestNewRecoveryHandler_written(t *testing.T) {
+	h := sklog.NewAccessLogHandler(sklog.NewLogger(log.NewNopLogger()), sklog.NewRecoveryHandler(sklog.NewLogger(log.NewNopLogger()), http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusAccepted)
+		panic("too late")
+	})))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
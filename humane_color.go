@@ -0,0 +1,79 @@
+package sklog
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-kit/kit/log/term"
+)
+
+const (
+	colorReset  = "\x1b[0m"
+	colorBold   = "\x1b[1m"
+	colorDim    = "\x1b[2m"
+	colorRed    = "\x1b[31m"
+	colorYellow = "\x1b[33m"
+	colorBlue   = "\x1b[34m"
+	colorGray   = "\x1b[90m"
+)
+
+// WithColor forces colored output on or off.
+// By default color is enabled if the writer is a terminal and NO_COLOR environment variable is not set or empty.
+// It has no effect on test logger.
+func WithColor(enabled bool) HumaneOption {
+	return func(hc *humaneConfig) {
+		hc.forceColor = &enabled
+	}
+}
+
+// colorEnabled reports whether output written to given writer should be colored by default.
+func colorEnabled(w io.Writer) bool {
+	if noColor() {
+		return false
+	}
+
+	return term.IsTerminal(w)
+}
+
+// noColor reports whether NO_COLOR environment variable asks for monochrome output.
+// Following https://no-color.org, an empty value does not.
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// isColored reports whether formatter writing to given writer should use colors.
+func isColored(w io.Writer) bool {
+	hb, ok := w.(*humaneBuffer)
+	return ok && hb.color
+}
+
+func levelColor(level interface{}) string {
+	switch level {
+	case LevelDebug:
+		return colorGray
+	case LevelInfo:
+		return colorBlue
+	case LevelWarning:
+		return colorYellow
+	case LevelError, LevelFatal, LevelPanic:
+		return colorRed
+	default:
+		return ""
+	}
+}
+
+// colorize wraps given text in color escape codes, trailing whitespace is left outside,
+// so padding is not affected.
+func colorize(color, s string) string {
+	if color == "" {
+		return s
+	}
+
+	trimmed := strings.TrimRight(s, " \t")
+	if trimmed == "" {
+		return s
+	}
+
+	return color + trimmed + colorReset + s[len(trimmed):]
+}
@@ -0,0 +1,227 @@
+package sklog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-kit/kit/log/term"
+)
+
+const (
+	// minMessageWidth is the narrowest message column used on a terminal.
+	minMessageWidth = 20
+	// defaultMessageWidth is the message column used if terminal width is unknown.
+	defaultMessageWidth = 60
+)
+
+// Overflow defines what happens with a message that does not fit into the message column.
+type Overflow int
+
+const (
+	// OverflowWrap wraps the message, following lines are written beneath, aligned with the column. It is the default.
+	OverflowWrap Overflow = iota
+	// OverflowElide shortens the message, "…" marks the cut.
+	OverflowElide
+)
+
+// WithTerminalWidth sets width of the output, instead of detecting it (and following terminal resizes).
+// Zero disables terminal aware layout, message column is then 60 characters wide.
+func WithTerminalWidth(width int) HumaneOption {
+	return func(hc *humaneConfig) {
+		hc.width = &width
+	}
+}
+
+// WithOverflow sets how messages that do not fit into the message column are handled. Default is OverflowWrap.
+func WithOverflow(overflow Overflow) HumaneOption {
+	return func(hc *humaneConfig) {
+		hc.overflow = overflow
+	}
+}
+
+const (
+	// columnsResetLines is the number of lines after which remembered column widths are forgotten,
+	// so a single long value does not pad following lines forever.
+	columnsResetLines = 100
+	// widthRefreshInterval is how often width of the terminal is checked again, to follow resizes.
+	widthRefreshInterval = time.Second
+)
+
+// terminalWidth returns width of the terminal given writer writes to, or zero if it is not a terminal.
+// COLUMNS environment variable takes precedence over the detected width.
+func terminalWidth(w io.Writer) int {
+	if !term.IsTerminal(w) {
+		return 0
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if f, ok := w.(*os.File); ok {
+		return windowWidth(f.Fd())
+	}
+
+	return 0
+}
+
+// layout holds state shared by consecutive lines: width of the output
+// and widths of trailing key/value pairs, so they can be aligned across lines.
+type layout struct {
+	mu      sync.Mutex
+	writer  io.Writer
+	fixed   bool
+	width   int
+	checked time.Time
+	lines   int
+	columns []column
+}
+
+// column is a trailing key/value pair at given position of a line.
+type column struct {
+	key   string
+	width int
+}
+
+func newLayout(w io.Writer, width *int) *layout {
+	if width != nil {
+		return &layout{fixed: true, width: *width}
+	}
+
+	return &layout{writer: w, width: terminalWidth(w), checked: time.Now()}
+}
+
+// line is called once per record, it returns width of the output. Width of a terminal is checked again
+// from time to time, remembered column widths are forgotten if it changes or after columnsResetLines lines.
+func (l *layout) line() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lines++
+	if l.lines%columnsResetLines == 0 {
+		l.columns = l.columns[:0]
+	}
+	if !l.fixed && time.Since(l.checked) > widthRefreshInterval {
+		l.checked = time.Now()
+		if width := terminalWidth(l.writer); width != l.width {
+			l.width = width
+			l.columns = l.columns[:0]
+		}
+	}
+
+	return l.width
+}
+
+// pad returns number of spaces needed to align pair of given key and width, written at given position of a line,
+// with the previous ones. Pairs are aligned only if previous lines had the same key at that position.
+func (l *layout) pad(position int, key string, width int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for len(l.columns) <= position {
+		l.columns = append(l.columns, column{})
+	}
+	c := &l.columns[position]
+	if c.key != key {
+		*c = column{key: key, width: width}
+		return 0
+	}
+	if c.width > width {
+		return c.width - width
+	}
+	c.width = width
+
+	return 0
+}
+
+type messageFormatter struct{}
+
+// NewMessageFormatter allocates KeyFormatter that writes message in a column.
+// If the output is a terminal, width of the column depends on width of the terminal, otherwise it is 60 characters.
+func NewMessageFormatter() KeyFormatter {
+	return messageFormatter{}
+}
+
+// Key implements KeyFormatter interface.
+func (messageFormatter) Key() string {
+	return KeyMessage
+}
+
+// Format implements Formatter interface.
+func (messageFormatter) Format(w io.Writer, v interface{}) (int, error) {
+	hb, ok := w.(*humaneBuffer)
+	if !ok || hb.width <= 0 {
+		return fmt.Fprintf(w, formatMessage, v)
+	}
+
+	prefix := hb.lineWidth()
+	col := messageWidth(hb.width, prefix)
+	hb.indent = prefix + 2
+
+	msg := fmt.Sprint(v)
+	if utf8.RuneCountInString(msg) > col {
+		if hb.overflow == OverflowElide {
+			msg = strings.TrimRight(string([]rune(msg)[:col-1]), " ") + "…"
+		} else {
+			lines := wrapText(msg, col)
+			msg = lines[0]
+			for _, line := range lines[1:] {
+				hb.after = append(hb.after, "\n"+strings.Repeat(" ", hb.indent)+line)
+			}
+		}
+	}
+
+	return fmt.Fprintf(w, "- %-*s ", col, msg)
+}
+
+// messageWidth returns width of the message column, roughly two thirds of what is left after the prefix.
+// The remaining third is left for trailing key/value pairs.
+func messageWidth(width, prefix int) int {
+	available := width - prefix - 3 // "- " before and " " after the message
+	if col := available * 2 / 3; col > minMessageWidth {
+		return col
+	}
+
+	return minMessageWidth
+}
+
+// wrapText splits text into lines not longer than given width, breaking at spaces if possible.
+func wrapText(text string, width int) []string {
+	var (
+		lines []string
+		line  []rune
+	)
+	for _, word := range strings.Fields(text) {
+		w := []rune(word)
+		if len(line) > 0 && len(line)+1+len(w) > width {
+			lines = append(lines, string(line))
+			line = line[:0]
+		}
+		for len(w) > width {
+			if len(line) > 0 {
+				lines = append(lines, string(line))
+				line = line[:0]
+			}
+			lines = append(lines, string(w[:width]))
+			w = w[width:]
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, w...)
+	}
+	if len(line) > 0 || len(lines) == 0 {
+		lines = append(lines, string(line))
+	}
+
+	return lines
+}
+
+// visibleWidth returns number of characters given text occupies on a terminal.
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(ansiCodes.ReplaceAllString(s, ""))
+}
@@ -0,0 +1,114 @@
+package sklog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMessageFormatter(t *testing.T) {
+	formatter := NewSequentialFormatter(NewKeyFormatter(formatBraces, KeyLevel), NewMessageFormatter())
+
+	cases := map[string]struct {
+		opts     []HumaneOption
+		msg      string
+		expected string
+	}{
+		"not-terminal": {
+			msg:      "log message",
+			expected: "[info] - " + pad("log message", 60) + " \n",
+		},
+		"terminal": {
+			opts:     []HumaneOption{WithTerminalWidth(80)},
+			msg:      "log message",
+			expected: "[info] - " + pad("log message", 46) + " \n",
+		},
+		"narrow": {
+			opts:     []HumaneOption{WithTerminalWidth(30)},
+			msg:      "log message",
+			expected: "[info] - " + pad("log message", minMessageWidth) + " \n",
+		},
+		"wrap": {
+			opts:     []HumaneOption{WithTerminalWidth(40)},
+			msg:      "first second third fourth fifth",
+			expected: "[info] - " + pad("first second third", 20) + " \n         fourth fifth\n",
+		},
+		"elide": {
+			opts:     []HumaneOption{WithTerminalWidth(40), WithOverflow(OverflowElide)},
+			msg:      "first second third fourth fifth",
+			expected: "[info] - first second third…  \n",
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			l := NewHumaneLogger(b, formatter, c.opts...)
+
+			if assert.NoError(t, l.Log(KeyLevel, LevelInfo, KeyMessage, c.msg)) {
+				assert.Equal(t, c.expected, b.String())
+			}
+		})
+	}
+}
+
+func TestHumaneLogger_Log_alignment(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewHumaneLogger(b, NewSequentialFormatter(NewKeyFormatter("%v ", KeyMessage)), WithTerminalWidth(80))
+
+	l.Log(KeyMessage, "a", "user", "john.doe", "status", 200)
+	l.Log(KeyMessage, "b", "user", "jane", "status", 404)
+
+	lines := strings.Split(b.String(), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.Equal(t, "a user=john.doe  status=200  ", lines[0])
+		assert.Equal(t, "b user=jane      status=404  ", lines[1])
+	}
+}
+
+func TestHumaneLogger_Log_alignmentPosition(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewHumaneLogger(b, NewSequentialFormatter(NewKeyFormatter("%v ", KeyMessage)), WithTerminalWidth(80))
+
+	l.Log(KeyMessage, "a", "user", "john.doe", "status", 200)
+	l.Log(KeyMessage, "b", "status", 404, "user", "jane")
+
+	lines := strings.Split(b.String(), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.Equal(t, "b status=404  user=jane  ", lines[1])
+	}
+}
+
+func TestHumaneLogger_Log_alignmentReset(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewHumaneLogger(b, NewSequentialFormatter(NewKeyFormatter("%v ", KeyMessage)), WithTerminalWidth(80))
+
+	l.Log(KeyMessage, "a", "user", "john.doe", "status", 200)
+	for i := 1; i < columnsResetLines; i++ {
+		b.Reset()
+		l.Log(KeyMessage, "b", "user", "jane", "status", 404)
+	}
+
+	assert.Equal(t, "b user=jane  status=404  \n", b.String())
+}
+
+func TestHumaneLogger_Log_wrapKeyvals(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewHumaneLogger(b, NewSequentialFormatter(NewMessageFormatter()), WithTerminalWidth(60))
+
+	l.Log(KeyMessage, "log message", "first", "1111111111", "second", "2222222222")
+
+	assert.Equal(t, "- "+pad("log message", 38)+" first=1111111111  \n  second=2222222222  \n", b.String())
+}
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, []string{"first", "second"}, wrapText("first second", 6))
+	assert.Equal(t, []string{"abc", "def", "gh"}, wrapText("abcdefgh", 3))
+	assert.Equal(t, []string{""}, wrapText("", 3))
+}
+
+func pad(s string, n int) string {
+	return s + strings.Repeat(" ", n-len(s))
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
-
+	"strconv"
 	"strings"
 
 	"github.com/go-kit/kit/log"
@@ -26,38 +26,106 @@ var (
 		NewKeyFormatter(formatBraces, KeyHTTPMethod),
 		NewKeyFormatter(formatBraces, KeyHTTPPath),
 		NewKeyFormatter(formatBraces, KeyHTTPStatus),
-		NewKeyFormatter(formatMessage, KeyMessage),
+		NewMessageFormatter(),
 	)
 )
 
+// HumaneOption configures logger allocated by NewHumaneLogger or NewTestLogger.
+type HumaneOption func(*humaneConfig)
+
+type humaneConfig struct {
+	forceColor       *bool
+	order            KeyOrder
+	duplicates       DuplicatePolicy
+	reservedKeyLog   bool
+	multiline        MultilineMode
+	truncate         int
+	truncationMarker string
+	width            *int
+	overflow         Overflow
+}
+
+func newHumaneConfig(opts []HumaneOption) humaneConfig {
+	hc := humaneConfig{order: InsertionOrder}
+	for _, opt := range opts {
+		opt(&hc)
+	}
+
+	return hc
+}
+
+// humaneBuffer is a buffer passed to formatters. Apart from collecting output,
+// it tells them whether they are allowed to use colors, in what order trailing keys should be written
+// and how wide the output can be.
+type humaneBuffer struct {
+	bytes.Buffer
+	color    bool
+	keys     []string
+	order    KeyOrder
+	width    int
+	overflow Overflow
+	layout   *layout
+	// indent is a column at which wrapped lines start.
+	indent int
+	// after holds lines that are written beneath the record line.
+	after []string
+}
+
+// lineWidth returns visible width of the line written so far.
+func (hb *humaneBuffer) lineWidth() int {
+	line := hb.String()
+	if i := strings.LastIndexByte(line, '\n'); i >= 0 {
+		line = line[i+1:]
+	}
+
+	return visibleWidth(line)
+}
+
 type humaneLogger struct {
 	io.Writer
+	humaneConfig
 	formatter Formatter
+	color     bool
+	layout    *layout
 }
 
 // NewHumaneLoggerWithFormatters like NewHumaneLogger allocates new instance,
 // but allow to pass custom collection of formatters.
-func NewHumaneLogger(writer io.Writer, formatter Formatter) log.Logger {
-	return &humaneLogger{
-		Writer:    writer,
-		formatter: formatter,
+// Output is colored if the writer is a terminal, see WithColor.
+// Trailing keys are written in the order they were passed, see WithKeyOrder.
+func NewHumaneLogger(writer io.Writer, formatter Formatter, opts ...HumaneOption) log.Logger {
+	hc := newHumaneConfig(opts)
+	hl := &humaneLogger{
+		Writer:       writer,
+		humaneConfig: hc,
+		formatter:    formatter,
+		color:        colorEnabled(writer),
+		layout:       newLayout(writer, hc.width),
 	}
+	if hc.forceColor != nil {
+		hl.color = *hc.forceColor
+	}
+
+	return hl
 }
 
 // Log implements Logger interface.
 func (hl *humaneLogger) Log(keyvals ...interface{}) (err error) {
-	n := (len(keyvals) + 1) / 2 // +1 to handle case when len is odd
-	m := make(map[string]interface{}, n)
-	b := bytes.NewBuffer(nil)
+	m, keys, duplicates := merge(keyvals, hl.duplicates)
+	b := &humaneBuffer{
+		color:    hl.color,
+		keys:     keys,
+		order:    hl.order,
+		width:    hl.layout.line(),
+		overflow: hl.overflow,
+		layout:   hl.layout,
+	}
 
-	for i := 0; i < len(keyvals); i += 2 {
-		k := keyvals[i]
-		var v interface{} = log.ErrMissingValue
-		if i+1 < len(keyvals) {
-			v = keyvals[i+1]
-		}
-		merge(m, k, v)
+	st, _ := m[KeyStackTrace].(StackTrace)
+	if st != nil {
+		delete(m, KeyStackTrace)
 	}
+	blocks := hl.renderValues(m, keys)
 
 	_, err = hl.formatter.Format(b, m)
 	if err != nil {
@@ -65,7 +133,23 @@ func (hl *humaneLogger) Log(keyvals ...interface{}) (err error) {
 		return err
 	}
 
+	for _, line := range b.after {
+		b.WriteString(line)
+	}
+	for _, block := range blocks {
+		b.WriteString(block)
+	}
+	for _, frame := range st {
+		b.WriteString("\n\t")
+		b.WriteString(frame)
+	}
 	b.WriteRune('\n')
+	if hl.reservedKeyLog {
+		for _, warning := range reservedKeyWarnings(duplicates) {
+			b.WriteString(warning)
+			b.WriteRune('\n')
+		}
+	}
 	_, err = b.WriteTo(hl.Writer)
 
 	return
@@ -110,7 +194,15 @@ func (kf *keyFormatter) Key() string {
 }
 
 func (kf *keyFormatter) Format(w io.Writer, v interface{}) (int, error) {
-	return kf.function(w, v)
+	if kf.key != KeyLevel || !isColored(w) {
+		return kf.function(w, v)
+	}
+
+	b := bytes.NewBuffer(nil)
+	if _, err := kf.function(b, v); err != nil {
+		return 0, err
+	}
+	return io.WriteString(w, colorize(levelColor(v), b.String()))
 }
 
 type sequentialFormatter struct {
@@ -155,8 +247,38 @@ func (sf *sequentialFormatter) Format(w io.Writer, v interface{}) (n int, err er
 
 func writeKV(w io.Writer, m map[string]interface{}) (n int, err error) {
 	var n1 int
-	for key, value := range m {
-		n1, err = fmt.Fprintf(w, "%s=%v  ", key, value)
+	format := "%s=%v  "
+	if isColored(w) {
+		format = colorDim + "%s=" + colorReset + colorBold + "%v" + colorReset + "  "
+	}
+	hb, layout := w.(*humaneBuffer)
+	layout = layout && hb.width > 0
+	var line int
+	if layout {
+		line = hb.lineWidth()
+	}
+	for i, key := range orderedKeys(w, m) {
+		if !layout {
+			n1, err = fmt.Fprintf(w, format, key, m[key])
+			n += n1
+			if err != nil {
+				return
+			}
+			continue
+		}
+
+		pair := fmt.Sprintf(format, key, m[key])
+		width := visibleWidth(pair)
+		if line > hb.indent && line+width > hb.width {
+			// Pair does not fit, it continues in the next line, aligned with the message.
+			pair = "\n" + strings.Repeat(" ", hb.indent) + pair
+			line = hb.indent
+		}
+		padding := hb.layout.pad(i, key, width)
+		pair += strings.Repeat(" ", padding)
+		line += width + padding
+
+		n1, err = io.WriteString(w, pair)
 		n += n1
 		if err != nil {
 			return
@@ -165,20 +287,66 @@ func writeKV(w io.Writer, m map[string]interface{}) (n int, err error) {
 
 	return
 }
-func merge(dst map[string]interface{}, k, v interface{}) {
-	var key string
+
+// merge merges key/value pairs into a map, resolving duplicated keys using given policy.
+// It returns keys in the order they were seen for the first time and keys that occurred more than once.
+func merge(keyvals []interface{}, policy DuplicatePolicy) (m map[string]interface{}, keys, duplicates []string) {
+	n := (len(keyvals) + 1) / 2 // +1 to handle case when len is odd
+	m = make(map[string]interface{}, n)
+	keys = make([]string, 0, n)
+
+	for i := 0; i < len(keyvals); i += 2 {
+		key := keyString(keyvals[i])
+		var v interface{} = log.ErrMissingValue
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		if x, ok := v.(error); ok {
+			v = safeError(x)
+		}
+
+		prev, ok := m[key]
+		if !ok {
+			m[key] = v
+			keys = append(keys, key)
+			continue
+		}
+
+		duplicates = append(duplicates, key)
+		switch policy {
+		case DuplicateKeepFirst:
+		case DuplicateRename:
+			for j := 1; ; j++ {
+				name := key + "_" + strconv.Itoa(j)
+				if _, ok := m[name]; !ok {
+					m[name] = v
+					keys = append(keys, name)
+					break
+				}
+			}
+		case DuplicateCollect:
+			if c, ok := prev.(collected); ok {
+				m[key] = append(c, v)
+			} else {
+				m[key] = collected{prev, v}
+			}
+		default:
+			m[key] = v
+		}
+	}
+
+	return m, keys, duplicates
+}
+
+func keyString(k interface{}) string {
 	switch x := k.(type) {
 	case string:
-		key = x
+		return x
 	case fmt.Stringer:
-		key = safeString(x)
+		return safeString(x)
 	default:
-		key = fmt.Sprint(x)
-	}
-	if x, ok := v.(error); ok {
-		v = safeError(x)
+		return fmt.Sprint(x)
 	}
-	dst[key] = v
 }
 
 func safeString(str fmt.Stringer) (s string) {
@@ -2,6 +2,8 @@ package sklog
 
 import (
 	"bytes"
+	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -38,3 +40,75 @@ func TestHumaneLogger_Log(t *testing.T) {
 		assert.Equal(t, e.String(), b.String())
 	}
 }
+
+func TestHumaneLogger_Log_stackTrace(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewHumaneLogger(b, NewSequentialFormatter(NewKeyFormatter("%v ", KeyMessage)))
+
+	err := l.Log(
+		KeyMessage, "log message",
+		KeyStackTrace, StackTrace{"main.run /src/main.go:10", "main.main /src/main.go:3"},
+	)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "log message \n\tmain.run /src/main.go:10\n\tmain.main /src/main.go:3\n", b.String())
+	}
+}
+
+func TestHumaneLogger_Log_nestedSubsystem(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewLogger(
+		NewHumaneLogger(b, DefaultHTTPFormatter),
+		WithTimestampFunc(func() string { return "now" }),
+	)
+
+	l.WithSubsystem("api").WithSubsystem("auth").Info("log message")
+
+	assert.Equal(t, "[now] [info ] [api.auth] - "+fmt.Sprintf("%-60v", "log message")+" \n", b.String())
+}
+
+func TestHumaneLogger_Log_color(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewHumaneLogger(b, NewSequentialFormatter(
+		NewKeyFormatter(formatBracesLevel, KeyLevel),
+		NewKeyFormatter("%v ", KeyMessage),
+	), WithColor(true))
+
+	err := l.Log(KeyLevel, LevelWarning, KeyMessage, "log message", "field1", "value1")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, colorYellow+"[warn ]"+colorReset+" log message "+colorDim+"field1="+colorReset+colorBold+"value1"+colorReset+"  \n", b.String())
+	}
+}
+
+func TestHumaneLogger_Log_noColor(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewHumaneLogger(b, NewSequentialFormatter(NewKeyFormatter(formatBracesLevel, KeyLevel)))
+
+	err := l.Log(KeyLevel, LevelError)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "[error] \n", b.String())
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	assert.False(t, colorEnabled(bytes.NewBuffer(nil)))
+
+	t.Setenv("NO_COLOR", "")
+	assert.False(t, noColor())
+
+	t.Setenv("NO_COLOR", "1")
+	assert.True(t, noColor())
+	assert.False(t, colorEnabled(os.Stdout))
+}
+
+func TestLevelColor(t *testing.T) {
+	assert.Equal(t, colorGray, levelColor(LevelDebug))
+	assert.Equal(t, colorBlue, levelColor(LevelInfo))
+	assert.Equal(t, colorYellow, levelColor(LevelWarning))
+	assert.Equal(t, colorRed, levelColor(LevelError))
+	assert.Equal(t, colorRed, levelColor(LevelFatal))
+	assert.Equal(t, colorRed, levelColor(LevelPanic))
+	assert.Equal(t, "", levelColor("custom"))
+}
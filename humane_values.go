@@ -0,0 +1,126 @@
+package sklog
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MultilineMode defines how humane logger renders values that span multiple lines.
+type MultilineMode int
+
+const (
+	// MultilineIndent moves multi-line values beneath the line, indented. It is the default.
+	MultilineIndent MultilineMode = iota
+	// MultilineQuote keeps multi-line values in the line, quoted.
+	MultilineQuote
+)
+
+// DefaultTruncationMarker is appended to values shortened because of WithTruncation.
+const DefaultTruncationMarker = "…"
+
+// WithMultilineMode sets how values that contain new lines are rendered. Default is MultilineIndent.
+func WithMultilineMode(mode MultilineMode) HumaneOption {
+	return func(hc *humaneConfig) {
+		hc.multiline = mode
+	}
+}
+
+// WithTruncation caps values at given number of characters, shortened values end with given marker.
+// If marker is empty, DefaultTruncationMarker is used.
+func WithTruncation(max int, marker string) HumaneOption {
+	if marker == "" {
+		marker = DefaultTruncationMarker
+	}
+
+	return func(hc *humaneConfig) {
+		hc.truncate = max
+		hc.truncationMarker = marker
+	}
+}
+
+// renderValues prepares values of a record for humane output.
+// Maps, slices and structs become compact JSON and long values are truncated, except values of reserved keys
+// (like msg or stacktrace), which are kept as they are unless collected by DuplicateCollect.
+// Multi-line values are either quoted or removed from the map, then they are returned as blocks
+// that should be written beneath the line.
+func (hc *humaneConfig) renderValues(m map[string]interface{}, keys []string) []string {
+	var blocks []string
+	for _, key := range sortKeys(keys, m, hc.order) {
+		v := m[key]
+		_, reserved := reservedKeys[key]
+		if _, ok := v.(collected); ok || !reserved {
+			v = structuredValue(v)
+			if s, ok := v.(string); ok {
+				v = hc.truncateString(s)
+			}
+		}
+
+		s, ok := v.(string)
+		if !ok || !strings.Contains(s, "\n") {
+			m[key] = v
+			continue
+		}
+
+		if hc.multiline == MultilineQuote {
+			m[key] = strconv.Quote(s)
+			continue
+		}
+
+		lines := strings.Split(strings.TrimRight(strings.Replace(s, "\r\n", "\n", -1), "\n"), "\n")
+		if key == KeyMessage {
+			// Message stays in its column, only following lines go beneath.
+			m[key] = lines[0]
+			blocks = append(blocks, "\n\t"+strings.Join(lines[1:], "\n\t"))
+			continue
+		}
+
+		delete(m, key)
+		blocks = append(blocks, "\n\t"+key+":\n\t\t"+strings.Join(lines, "\n\t\t"))
+	}
+
+	return blocks
+}
+
+func (hc *humaneConfig) truncateString(s string) string {
+	if hc.truncate <= 0 || utf8.RuneCountInString(s) <= hc.truncate {
+		return s
+	}
+
+	return string([]rune(s)[:hc.truncate]) + hc.truncationMarker
+}
+
+// structuredValue returns compact JSON representation of maps, slices, arrays and structs.
+// Values that know how to print themselves (fmt.Stringer, error) are left untouched,
+// so are structs that JSON would render as {} although they have fields (e.g. unexported ones).
+func structuredValue(v interface{}) interface{} {
+	switch v.(type) {
+	case nil, string, []byte, fmt.Stringer, error:
+		return v
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return v
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return v
+		}
+		if rv.Kind() == reflect.Struct && rv.NumField() > 0 && string(b) == "{}" {
+			return v
+		}
+		return string(b)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,71 @@
+package sklog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumaneLogger_Log_multiline(t *testing.T) {
+	formatter := NewSequentialFormatter(NewKeyFormatter("%v ", KeyMessage))
+	keyvals := []interface{}{
+		KeyMessage, "query failed\nsecond line",
+		"query", "SELECT *\r\nFROM users\n",
+		"table", "users",
+		KeyStackTrace, StackTrace{"main.main /src/main.go:3"},
+	}
+
+	b := bytes.NewBuffer(nil)
+	if assert.NoError(t, NewHumaneLogger(b, formatter).Log(keyvals...)) {
+		assert.Equal(t, "query failed table=users  \n\tsecond line\n\tquery:\n\t\tSELECT *\n\t\tFROM users\n\tmain.main /src/main.go:3\n", b.String())
+	}
+
+	b.Reset()
+	if assert.NoError(t, NewHumaneLogger(b, formatter, WithMultilineMode(MultilineQuote)).Log(keyvals...)) {
+		assert.Equal(t, `"query failed\nsecond line" query="SELECT *\r\nFROM users\n"  table=users  `+"\n\tmain.main /src/main.go:3\n", b.String())
+	}
+}
+
+func TestHumaneLogger_Log_structured(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	b := bytes.NewBuffer(nil)
+	l := NewHumaneLogger(b, NewSequentialFormatter())
+
+	err := l.Log(
+		"map", map[string]int{"a": 1},
+		"slice", []string{"a", "b"},
+		"struct", user{Name: "john", Age: 30},
+		"pointer", &user{Name: "jane"},
+		"unexported", struct{ a int }{1},
+		"number", 5,
+	)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, `map={"a":1}  slice=["a","b"]  struct={"name":"john","age":30}  pointer={"name":"jane","age":0}  unexported={1}  number=5  `+"\n", b.String())
+	}
+}
+
+func TestHumaneLogger_Log_truncation(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewHumaneLogger(b, NewSequentialFormatter(), WithTruncation(5, ""))
+
+	if assert.NoError(t, l.Log("short", "abc", "long", "abcdefgh", "json", []int{1, 2, 3})) {
+		assert.Equal(t, "short=abc  long=abcde…  json=[1,2,…  \n", b.String())
+	}
+
+	b.Reset()
+	if assert.NoError(t, l.Log(KeyMessage, "long message", KeyErrorChain, []string{"a", "b"})) {
+		assert.Equal(t, "msg=long message  error_chain=[a b]  \n", b.String())
+	}
+
+	b.Reset()
+	l = NewHumaneLogger(b, NewSequentialFormatter(), WithTruncation(3, "[...]"))
+	if assert.NoError(t, l.Log("long", "abcdefgh")) {
+		assert.Equal(t, "long=abc[...]  \n", b.String())
+	}
+}
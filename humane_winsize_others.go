@@ -0,0 +1,8 @@
+//go:build !(linux || darwin || freebsd || openbsd) || appengine
+
+package sklog
+
+// windowWidth returns zero, width of the terminal is known only if COLUMNS environment variable is set.
+func windowWidth(fd uintptr) int {
+	return 0
+}
@@ -0,0 +1,20 @@
+//go:build (linux || darwin || freebsd || openbsd) && !appengine
+
+package sklog
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// windowWidth returns number of columns of the terminal referred by given file descriptor, zero if unknown.
+func windowWidth(fd uintptr) int {
+	var ws struct {
+		row, col, xpixel, ypixel uint16
+	}
+	if _, _, err := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&ws))); err != 0 {
+		return 0
+	}
+
+	return int(ws.col)
+}
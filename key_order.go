@@ -0,0 +1,73 @@
+package sklog
+
+import (
+	"io"
+	"sort"
+)
+
+// KeyOrder sorts keys of a record in place. Keys are given in the order they were passed to the logger.
+type KeyOrder func(keys []string)
+
+var (
+	// InsertionOrder leaves keys in the order they were passed to the logger.
+	InsertionOrder KeyOrder = func([]string) {}
+	// AlphabeticalOrder sorts keys alphabetically.
+	AlphabeticalOrder KeyOrder = sort.Strings
+)
+
+// PriorityOrder puts given keys first, in given order. Remaining keys keep insertion order.
+func PriorityOrder(priority ...string) KeyOrder {
+	rank := make(map[string]int, len(priority))
+	for i, key := range priority {
+		rank[key] = i
+	}
+
+	return func(keys []string) {
+		sort.SliceStable(keys, func(i, j int) bool {
+			ri, oki := rank[keys[i]]
+			rj, okj := rank[keys[j]]
+			switch {
+			case oki && okj:
+				return ri < rj
+			default:
+				return oki && !okj
+			}
+		})
+	}
+}
+
+// WithKeyOrder sets order in which trailing key/value pairs are written. Default is InsertionOrder.
+func WithKeyOrder(order KeyOrder) HumaneOption {
+	return func(hc *humaneConfig) {
+		hc.order = order
+	}
+}
+
+// orderedKeys returns keys of given map in the order given writer asks for.
+// If the writer does not come from humane logger, keys are sorted alphabetically, so output is deterministic anyway.
+func orderedKeys(w io.Writer, m map[string]interface{}) []string {
+	hb, ok := w.(*humaneBuffer)
+	if !ok {
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	return sortKeys(hb.keys, m, hb.order)
+}
+
+// sortKeys returns those of given keys that are still present in the map, sorted using given order.
+func sortKeys(keys []string, m map[string]interface{}, order KeyOrder) []string {
+	res := make([]string, 0, len(m))
+	for _, key := range keys {
+		if _, ok := m[key]; ok {
+			res = append(res, key)
+		}
+	}
+	order(res)
+
+	return res
+}
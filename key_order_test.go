@@ -0,0 +1,77 @@
+package sklog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithKeyOrder(t *testing.T) {
+	keyvals := []interface{}{KeyMessage, "log message", "zeta", 1, "alpha", 2, KeyRequestID, "abc", "beta", 3}
+
+	cases := map[string]struct {
+		opts     []HumaneOption
+		expected string
+	}{
+		"insertion": {
+			expected: "log message zeta=1  alpha=2  request_id=abc  beta=3  \n",
+		},
+		"alphabetical": {
+			opts:     []HumaneOption{WithKeyOrder(AlphabeticalOrder)},
+			expected: "log message alpha=2  beta=3  request_id=abc  zeta=1  \n",
+		},
+		"priority": {
+			opts:     []HumaneOption{WithKeyOrder(PriorityOrder(KeyRequestID, "beta", "missing"))},
+			expected: "log message request_id=abc  beta=3  zeta=1  alpha=2  \n",
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			l := NewHumaneLogger(b, NewSequentialFormatter(NewKeyFormatter("%v ", KeyMessage)), c.opts...)
+
+			// Repeated to make sure map iteration order does not leak into the output.
+			for i := 0; i < 10; i++ {
+				b.Reset()
+				if assert.NoError(t, l.Log(keyvals...)) {
+					assert.Equal(t, c.expected, b.String())
+				}
+			}
+		})
+	}
+}
+
+func TestWithKeyOrder_duplicate(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := NewHumaneLogger(b, NewSequentialFormatter())
+
+	if assert.NoError(t, l.Log("a", 1, "b", 2, "a", 3)) {
+		assert.Equal(t, "a=3  b=2  \n", b.String())
+	}
+}
+
+func TestTestLogger_format(t *testing.T) {
+	keyvals := []interface{}{"zeta", 1, KeyMessage, "log message", "alpha", 2}
+
+	got, _ := NewTestLogger(t).(*testLogger).format(keyvals)
+	assert.Equal(t, "log message"+spaces(49)+"zeta=1  alpha=2  ", got)
+
+	got, _ = NewTestLogger(t, WithKeyOrder(AlphabeticalOrder)).(*testLogger).format(keyvals)
+	assert.Equal(t, "log message"+spaces(49)+"alpha=2  zeta=1  ", got)
+}
+
+func TestWriteKV(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+
+	_, err := writeKV(b, map[string]interface{}{"b": 2, "c": 3, "a": 1})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "a=1  b=2  c=3  ", b.String())
+	}
+}
+
+func spaces(n int) string {
+	return string(bytes.Repeat([]byte{' '}, n))
+}
@@ -0,0 +1,138 @@
+package sklog
+
+import (
+	"strings"
+
+	"github.com/go-kit/kit/log"
+)
+
+var levels = map[string]int{
+	LevelDebug:   0,
+	LevelInfo:    1,
+	LevelWarning: 2,
+	LevelError:   3,
+	LevelPanic:   4,
+	LevelFatal:   5,
+}
+
+// LevelEnabled reports whether level is equal to or more severe than min.
+// Levels unknown to sklog are always enabled, so is every level if min is unknown;
+// NewLevelFilter and NewAtomicLevel reject such min.
+func LevelEnabled(level, min string) bool {
+	l, ok := levels[level]
+	if !ok {
+		return true
+	}
+	m, ok := levels[min]
+	if !ok {
+		return true
+	}
+	return l >= m
+}
+
+// LevelFilterOption configures logger allocated by NewLevelFilter.
+type LevelFilterOption func(*levelFilter)
+
+// AllowSubsystem makes level filter pass records of given subsystems regardless of their level.
+// Nested subsystems are matched as well, allowing "billing" allows "billing.invoices".
+// The most specific of allowed and denied subsystems wins.
+func AllowSubsystem(subsystems ...string) LevelFilterOption {
+	return func(lf *levelFilter) {
+		for _, s := range subsystems {
+			lf.allow[s] = struct{}{}
+		}
+	}
+}
+
+// DenySubsystem makes level filter drop all records of given subsystems, including nested ones.
+func DenySubsystem(subsystems ...string) LevelFilterOption {
+	return func(lf *levelFilter) {
+		for _, s := range subsystems {
+			lf.deny[s] = struct{}{}
+		}
+	}
+}
+
+type levelFilter struct {
+	logger log.Logger
+	level  *AtomicLevel
+	allow  map[string]struct{}
+	deny   map[string]struct{}
+}
+
+// NewLevelFilter returns a Logger that drops records with level below min.
+// Level is taken from the KeyLevel value, records without it are passed through.
+// It panics if min is not one of Level* constants.
+func NewLevelFilter(logger log.Logger, min string, opts ...LevelFilterOption) log.Logger {
+	return NewAtomicLevelFilter(logger, NewAtomicLevel(min), opts...)
+}
+
+// NewAtomicLevelFilter works like NewLevelFilter, but threshold is read from given AtomicLevel on every call.
+// It makes possible to change the level at runtime.
+func NewAtomicLevelFilter(logger log.Logger, level *AtomicLevel, opts ...LevelFilterOption) log.Logger {
+	lf := &levelFilter{
+		logger: logger,
+		level:  level,
+		allow:  make(map[string]struct{}),
+		deny:   make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(lf)
+	}
+
+	return lf
+}
+
+// Log implements Logger interface.
+func (lf *levelFilter) Log(keyvals ...interface{}) error {
+	level, subsystem, hasSubsystem := levelAndSubsystem(keyvals)
+
+	if hasSubsystem {
+		switch denied, allowed := lf.subsystemRule(subsystem); {
+		case denied:
+			return nil
+		case allowed:
+			return lf.logger.Log(keyvals...)
+		}
+	}
+	if level != "" && !LevelEnabled(level, lf.level.min(subsystem)) {
+		return nil
+	}
+
+	return lf.logger.Log(keyvals...)
+}
+
+// subsystemRule reports whether given subsystem, or the closest subsystem it is nested in, is denied or allowed.
+func (lf *levelFilter) subsystemRule(subsystem string) (denied, allowed bool) {
+	for {
+		if _, ok := lf.deny[subsystem]; ok {
+			return true, false
+		}
+		if _, ok := lf.allow[subsystem]; ok {
+			return false, true
+		}
+		i := strings.LastIndexByte(subsystem, '.')
+		if i < 0 {
+			return false, false
+		}
+		subsystem = subsystem[:i]
+	}
+}
+
+// levelAndSubsystem returns last level and subsystem found in keyvals.
+func levelAndSubsystem(keyvals []interface{}) (level, subsystem string, hasSubsystem bool) {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		switch keyvals[i] {
+		case KeyLevel:
+			if s, ok := keyvals[i+1].(string); ok {
+				level = s
+			}
+		case KeySubsystem:
+			if s, ok := keyvals[i+1].(string); ok {
+				subsystem, hasSubsystem = s, true
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,103 @@
+package sklog_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelEnabled(t *testing.T) {
+	ordered := []string{
+		sklog.LevelDebug,
+		sklog.LevelInfo,
+		sklog.LevelWarning,
+		sklog.LevelError,
+		sklog.LevelPanic,
+		sklog.LevelFatal,
+	}
+
+	for i, min := range ordered {
+		for j, level := range ordered {
+			assert.Equal(t, j >= i, sklog.LevelEnabled(level, min), "level %s, min %s", level, min)
+		}
+	}
+
+	assert.True(t, sklog.LevelEnabled("custom", sklog.LevelFatal))
+	assert.True(t, sklog.LevelEnabled(sklog.LevelDebug, "custom"))
+}
+
+func TestLevelFilter_Log(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLevelFilter(log.NewJSONLogger(b), sklog.LevelWarning)
+
+	sklog.Debug(l, "debug message")
+	sklog.Info(l, "info message")
+	assert.Empty(t, b.String())
+
+	sklog.Warning(l, "warning message")
+	assert.Contains(t, b.String(), "warning message")
+	b.Reset()
+
+	sklog.Error(l, errors.New("sklog_test: example error"))
+	assert.Contains(t, b.String(), "sklog_test: example error")
+	b.Reset()
+
+	l.Log(sklog.KeyMessage, "without level")
+	assert.Contains(t, b.String(), "without level")
+}
+
+func TestLevelFilter_Log_subsystem(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLevelFilter(
+		log.NewJSONLogger(b),
+		sklog.LevelInfo,
+		sklog.AllowSubsystem("billing"),
+		sklog.DenySubsystem("grpc"),
+	)
+
+	sklog.Debug(l, "debug billing", sklog.KeySubsystem, "billing")
+	assert.Contains(t, b.String(), "debug billing")
+	b.Reset()
+
+	sklog.Error(l, errors.New("sklog_test: grpc error"), sklog.KeySubsystem, "grpc")
+	assert.Empty(t, b.String())
+
+	sklog.Debug(l, "debug other", sklog.KeySubsystem, "other")
+	assert.Empty(t, b.String())
+
+	sklog.Info(l, "info other", sklog.KeySubsystem, "other")
+	assert.Contains(t, b.String(), "info other")
+}
+
+func TestLevelFilter_Log_nestedSubsystem(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLevelFilter(
+		log.NewJSONLogger(b),
+		sklog.LevelInfo,
+		sklog.DenySubsystem("billing"),
+		sklog.AllowSubsystem("billing.invoices.debug"),
+	)
+
+	sklog.Info(l, "info billing", sklog.KeySubsystem, "billing.invoices")
+	assert.Empty(t, b.String())
+
+	sklog.Debug(l, "debug invoices", sklog.KeySubsystem, "billing.invoices.debug.sql")
+	assert.Contains(t, b.String(), "debug invoices")
+	b.Reset()
+
+	sklog.Info(l, "info billing2", sklog.KeySubsystem, "billing2")
+	assert.Contains(t, b.String(), "info billing2")
+}
+
+func TestNewLevelFilter_unknownLevel(t *testing.T) {
+	assert.PanicsWithError(t, "sklog: unknown level: warning", func() {
+		sklog.NewLevelFilter(log.NewNopLogger(), "warning")
+	})
+	assert.Panics(t, func() {
+		sklog.NewAtomicLevel("")
+	})
+}
@@ -0,0 +1,98 @@
+package sklog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type levelHandler struct {
+	level *AtomicLevel
+}
+
+type levelHandlerState struct {
+	Level      string            `json:"level"`
+	Subsystems map[string]string `json:"subsystems"`
+}
+
+type levelHandlerRequest struct {
+	Level     string `json:"level"`
+	Subsystem string `json:"subsystem,omitempty"`
+	TTL       string `json:"ttl,omitempty"`
+}
+
+type levelHandlerError struct {
+	Error string `json:"error"`
+}
+
+// NewLevelHandler returns http.Handler that exposes given AtomicLevel.
+//
+// GET responds with current level and per subsystem overrides.
+// PUT expects JSON body like {"level": "debug", "subsystem": "billing", "ttl": "10m"}.
+// Subsystem and ttl are optional, if ttl is given level is reverted after that time.
+// Empty level together with subsystem removes the override.
+func NewLevelHandler(level *AtomicLevel) http.Handler {
+	return &levelHandler{level: level}
+}
+
+// ServeHTTP implements http.Handler interface.
+func (lh *levelHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var req levelHandlerRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			lh.error(rw, http.StatusBadRequest, err)
+			return
+		}
+		if err := lh.apply(req); err != nil {
+			lh.error(rw, http.StatusBadRequest, err)
+			return
+		}
+	default:
+		rw.Header().Set("Allow", http.MethodGet+", "+http.MethodPut)
+		lh.error(rw, http.StatusMethodNotAllowed, nil)
+		return
+	}
+
+	lh.write(rw, http.StatusOK, levelHandlerState{
+		Level:      lh.level.Level(),
+		Subsystems: lh.level.SubsystemLevels(),
+	})
+}
+
+func (lh *levelHandler) apply(req levelHandlerRequest) error {
+	var ttl time.Duration
+	if req.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(req.TTL); err != nil {
+			return err
+		}
+		if ttl < 0 {
+			return fmt.Errorf("sklog: negative ttl: %s", req.TTL)
+		}
+	}
+
+	if req.Subsystem != "" && req.Level == "" {
+		lh.level.ResetSubsystemLevel(req.Subsystem)
+		return nil
+	}
+
+	return lh.level.SetSubsystemLevelFor(req.Subsystem, req.Level, ttl)
+}
+
+func (lh *levelHandler) error(rw http.ResponseWriter, status int, err error) {
+	msg := http.StatusText(status)
+	if err != nil {
+		msg = err.Error()
+	}
+
+	lh.write(rw, status, levelHandlerError{Error: msg})
+}
+
+func (lh *levelHandler) write(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	json.NewEncoder(rw).Encode(v)
+}
@@ -0,0 +1,78 @@
+package sklog_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelHandler_ServeHTTP(t *testing.T) {
+	al := sklog.NewAtomicLevel(sklog.LevelInfo)
+	h := sklog.NewLevelHandler(al)
+
+	success := []struct {
+		method   string
+		body     string
+		status   int
+		expected string
+	}{
+		{
+			method:   http.MethodGet,
+			status:   http.StatusOK,
+			expected: `{"level":"info","subsystems":{}}`,
+		},
+		{
+			method:   http.MethodPut,
+			body:     `{"level":"debug"}`,
+			status:   http.StatusOK,
+			expected: `{"level":"debug","subsystems":{}}`,
+		},
+		{
+			method:   http.MethodPut,
+			body:     `{"level":"error","subsystem":"grpc","ttl":"1h"}`,
+			status:   http.StatusOK,
+			expected: `{"level":"debug","subsystems":{"grpc":"error"}}`,
+		},
+		{
+			method:   http.MethodPut,
+			body:     `{"level":"verbose"}`,
+			status:   http.StatusBadRequest,
+			expected: `{"error":"sklog: unknown level: verbose"}`,
+		},
+		{
+			method:   http.MethodPut,
+			body:     `{"level":"info","ttl":"forever"}`,
+			status:   http.StatusBadRequest,
+			expected: `"error"`,
+		},
+		{
+			method:   http.MethodPut,
+			body:     `{"level":"info","ttl":"-5m"}`,
+			status:   http.StatusBadRequest,
+			expected: `{"error":"sklog: negative ttl: -5m"}`,
+		},
+		{
+			method:   http.MethodPut,
+			body:     `{"subsystem":"grpc"}`,
+			status:   http.StatusOK,
+			expected: `{"level":"debug","subsystems":{}}`,
+		},
+		{
+			method:   http.MethodPost,
+			status:   http.StatusMethodNotAllowed,
+			expected: `{"error":"Method Not Allowed"}`,
+		},
+	}
+
+	for _, data := range success {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(data.method, "/", strings.NewReader(data.body)))
+
+		assert.Equal(t, data.status, rec.Code, data.body)
+		assert.Contains(t, rec.Body.String(), data.expected, data.body)
+	}
+}
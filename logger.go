@@ -3,6 +3,8 @@ package sklog
 import (
 	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-kit/kit/log"
@@ -30,25 +32,308 @@ const (
 	KeyHTTPMethod = "http_method"
 	// KeyHTTPPath ...
 	KeyHTTPPath = "http_path"
+	// KeyHTTPRequestURI is a key under which unmodified request target (path and query) is logged.
+	KeyHTTPRequestURI = "http_request_uri"
+	// KeyHTTPBytes ...
+	KeyHTTPBytes = "http_bytes"
+	// KeyHTTPDuration ...
+	KeyHTTPDuration = "http_duration"
+	// KeyHTTPRemoteAddr ...
+	KeyHTTPRemoteAddr = "http_remote_addr"
+	// KeyHTTPUserAgent ...
+	KeyHTTPUserAgent = "http_user_agent"
+	// KeyHTTPProto ...
+	KeyHTTPProto = "http_proto"
+	// KeyHTTPReferer ...
+	KeyHTTPReferer = "http_referer"
+	// KeyHTTPHost ...
+	KeyHTTPHost = "http_host"
+	// KeyHTTPRetries ...
+	KeyHTTPRetries = "http_retries"
+	// KeyHTTPHeaders ...
+	KeyHTTPHeaders = "http_headers"
+	// KeyRequestID ...
+	KeyRequestID = "request_id"
 	// KeyTimestamp ...
 	KeyTimestamp = "timestamp"
 	// KeyLevel ...
 	KeyLevel = "level"
 	// KeyMessage ...
 	KeyMessage = "msg"
+	// KeyCaller ...
+	KeyCaller = "caller"
+	// KeyFunction ...
+	KeyFunction = "func"
+	// KeyStackTrace ...
+	KeyStackTrace = "stacktrace"
+	// KeyErrorChain ...
+	KeyErrorChain = "error_chain"
 )
 
 var (
-	timestampFunc = now
+	// stdMu serializes changes of the default instance, made by Set* functions.
+	stdMu sync.Mutex
+	// stdLogger holds the default instance, see std.
+	stdLogger atomic.Pointer[Logger]
 )
 
+func init() {
+	stdLogger.Store(&Logger{
+		logger:           log.NewLogfmtLogger(log.NewSyncWriter(os.Stderr)),
+		timestampFunc:    now,
+		contextErrorFunc: NewContextErrorGeneric,
+	})
+}
+
+// std returns default instance used by package level shorthands.
+// Its logger, writing logfmt to standard error, is used by FromContext if the context carries none.
+func std() *Logger {
+	return stdLogger.Load()
+}
+
+// setStd changes default instance using given option. Instance is replaced as a whole,
+// so that package level shorthands can be used concurrently with Set* functions.
+func setStd(opt LoggerOption) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+
+	l := *stdLogger.Load()
+	opt(&l)
+	stdLogger.Store(&l)
+}
+
 func now() string {
 	return time.Now().Format(time.RFC3339)
 }
 
 // SetTimestampFunc sets function that is used to populate timestamp field.
 func SetTimestampFunc(fn func() string) {
-	timestampFunc = fn
+	setStd(WithTimestampFunc(fn))
+}
+
+// Logger bundles log.Logger with configuration used by its shorthand methods.
+// Unlike package level functions it does not depend on any global state.
+type Logger struct {
+	logger           log.Logger
+	timestampFunc    func() string
+	contextErrorFunc func(log.Logger, error) *log.Context
+	callerMode       CallerMode
+	stackTraceDepth  int
+	subsystem        string
+	keyvals          []interface{}
+}
+
+// LoggerOption configures Logger allocated by NewLogger.
+type LoggerOption func(*Logger)
+
+// WithTimestampFunc sets function that is used to populate timestamp field.
+func WithTimestampFunc(fn func() string) LoggerOption {
+	return func(l *Logger) {
+		l.timestampFunc = fn
+	}
+}
+
+// WithContextErrorFunc sets function that creates context for logged errors.
+func WithContextErrorFunc(fn func(log.Logger, error) *log.Context) LoggerOption {
+	return func(l *Logger) {
+		l.contextErrorFunc = fn
+	}
+}
+
+// WithCallerMode sets whether and how records are annotated with call site information.
+func WithCallerMode(mode CallerMode) LoggerOption {
+	return func(l *Logger) {
+		l.callerMode = mode
+	}
+}
+
+// WithStackTraceDepth sets maximum number of frames captured by Error, Fatal and Panic.
+// Zero, the default, disables stack trace capturing.
+func WithStackTraceDepth(depth int) LoggerOption {
+	return func(l *Logger) {
+		l.stackTraceDepth = depth
+	}
+}
+
+// WithKeyvals sets key/value pairs that are added to every record.
+// Like With, it pads odd number of arguments with log.ErrMissingValue.
+func WithKeyvals(keyvals ...interface{}) LoggerOption {
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, log.ErrMissingValue)
+	}
+
+	return func(l *Logger) {
+		l.keyvals = append(l.keyvals[:len(l.keyvals):len(l.keyvals)], keyvals...)
+	}
+}
+
+// NewLogger allocates new Logger that writes to given logger.
+func NewLogger(logger log.Logger, opts ...LoggerOption) *Logger {
+	l := &Logger{
+		logger:           logger,
+		timestampFunc:    now,
+		contextErrorFunc: NewContextErrorGeneric,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// With returns child logger that adds given key/value pairs to every record.
+func (l *Logger) With(keyvals ...interface{}) *Logger {
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, log.ErrMissingValue)
+	}
+
+	child := *l
+	// Limiting the capacity makes sure that siblings do not share the backing array.
+	child.keyvals = append(l.keyvals[:len(l.keyvals):len(l.keyvals)], keyvals...)
+
+	return &child
+}
+
+// WithSubsystem returns child logger that logs under given subsystem.
+// If the logger has a subsystem already, they are joined using a dot (parent.child).
+func (l *Logger) WithSubsystem(name string) *Logger {
+	child := *l
+	if l.subsystem != "" {
+		child.subsystem = l.subsystem + "." + name
+	} else {
+		child.subsystem = name
+	}
+
+	return &child
+}
+
+// WithOptions returns child logger with given options applied.
+func (l *Logger) WithOptions(opts ...LoggerOption) *Logger {
+	child := *l
+	for _, opt := range opts {
+		opt(&child)
+	}
+
+	return &child
+}
+
+// Debug log message and given context with level debug.
+func (l *Logger) Debug(msg string, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.log(l.logger, LevelDebug, msg, keyval)
+}
+
+// Info log message and given context with level info.
+func (l *Logger) Info(msg string, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.log(l.logger, LevelInfo, msg, keyval)
+}
+
+// Warning log message and given context with level warning.
+func (l *Logger) Warning(msg string, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.log(l.logger, LevelWarning, msg, keyval)
+}
+
+// LogLevel log message and given context with given level.
+// It is meant for middlewares that derive level from an outcome, like HTTP status.
+func (l *Logger) LogLevel(level, msg string, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.log(l.logger, level, msg, keyval)
+}
+
+// Error log error and given context with level error.
+func (l *Logger) Error(err error, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.logError(l.logger, LevelError, err, keyval)
+}
+
+// Fatal log error and given context with level fatal and exists an application with status code 1.
+func (l *Logger) Fatal(err error, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.logError(l.logger, LevelFatal, err, keyval)
+	os.Exit(1)
+}
+
+// Panic log error and given context with level panic and panics.
+func (l *Logger) Panic(err error, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.logError(l.logger, LevelPanic, err, keyval)
+	panic(fmt.Sprint(append(keyval, KeyLevel, LevelPanic, KeyMessage, err)...))
+}
+
+// Recovered log value recovered from a panic and given context with level panic, without panicking again.
+// Errors are passed through the context error function, other values are formatted using fmt.
+// Stack trace is always added, DefaultStackTraceDepth frames deep if capturing is disabled.
+func (l *Logger) Recovered(v interface{}, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	err, ok := v.(error)
+	if !ok {
+		err = fmt.Errorf("%v", v)
+	}
+	rl := *l
+	if rl.stackTraceDepth <= 0 {
+		rl.stackTraceDepth = DefaultStackTraceDepth
+	}
+	rl.logError(l.logger, LevelPanic, err, keyval)
+}
+
+func (l *Logger) log(logger log.Logger, level, msg string, keyval []interface{}) {
+	if tl, ok := logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	logger.Log(l.record(keyval, KeyLevel, level, KeyMessage, msg)...)
+}
+
+func (l *Logger) logError(logger log.Logger, level string, err error, keyval []interface{}) {
+	if tl, ok := logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	kv := l.record(keyval, KeyLevel, level, KeyMessage, err.Error())
+	kv = appendStackTrace(kv, err, l.stackTraceDepth)
+
+	logger.Log(append(contextErrorRecord(l.contextErrorFunc, err), kv...)...)
+}
+
+// record builds key/value pairs of a single record: subsystem and default ones first, then given ones,
+// then those added by the shorthand, timestamp and call site.
+func (l *Logger) record(keyval []interface{}, suffix ...interface{}) []interface{} {
+	return l.recordDepth(0, keyval, suffix...)
+}
+
+// recordDepth works like record, but skips given number of additional frames when looking for the call site.
+func (l *Logger) recordDepth(depth int, keyval []interface{}, suffix ...interface{}) []interface{} {
+	kv := append(l.recordBase(keyval, suffix...), KeyTimestamp, l.timestampFunc())
+
+	return appendCaller(kv, l.callerMode, depth)
+}
+
+// recordBase builds key/value pairs of a single record without timestamp and call site.
+func (l *Logger) recordBase(keyval []interface{}, suffix ...interface{}) []interface{} {
+	kv := make([]interface{}, 0, len(l.keyvals)+len(keyval)+len(suffix)+8)
+	if l.subsystem != "" {
+		kv = append(kv, KeySubsystem, l.subsystem)
+	}
+	kv = append(kv, l.keyvals...)
+	kv = append(kv, keyval...)
+
+	return append(kv, suffix...)
 }
 
 // Log log message with timestamp.
@@ -56,7 +341,7 @@ func Log(logger log.Logger, keyval ...interface{}) {
 	if tl, ok := logger.(*testLogger); ok {
 		tl.t.Helper()
 	}
-	logger.Log(append(keyval, KeyTimestamp, timestampFunc())...)
+	logger.Log(std().record(keyval)...)
 }
 
 // Debug log message and given context with level debug.
@@ -64,7 +349,7 @@ func Debug(logger log.Logger, msg string, keyval ...interface{}) {
 	if tl, ok := logger.(*testLogger); ok {
 		tl.t.Helper()
 	}
-	logger.Log(append(keyval, KeyLevel, LevelDebug, KeyMessage, msg, KeyTimestamp, timestampFunc())...)
+	std().log(logger, LevelDebug, msg, keyval)
 }
 
 // Info log message and given context with level info.
@@ -72,7 +357,7 @@ func Info(logger log.Logger, msg string, keyval ...interface{}) {
 	if tl, ok := logger.(*testLogger); ok {
 		tl.t.Helper()
 	}
-	logger.Log(append(keyval, KeyLevel, LevelInfo, KeyMessage, msg, KeyTimestamp, timestampFunc())...)
+	std().log(logger, LevelInfo, msg, keyval)
 }
 
 // Warning log message using given logger.
@@ -80,7 +365,7 @@ func Warning(logger log.Logger, msg string, keyval ...interface{}) {
 	if tl, ok := logger.(*testLogger); ok {
 		tl.t.Helper()
 	}
-	logger.Log(append(keyval, KeyLevel, LevelWarning, KeyMessage, msg, KeyTimestamp, timestampFunc())...)
+	std().log(logger, LevelWarning, msg, keyval)
 }
 
 // Error log error using given logger.
@@ -88,7 +373,7 @@ func Error(logger log.Logger, err error, keyval ...interface{}) {
 	if tl, ok := logger.(*testLogger); ok {
 		tl.t.Helper()
 	}
-	contextErrorFunc(logger, err).Log(append(keyval, KeyLevel, LevelError, KeyMessage, err.Error(), KeyTimestamp, timestampFunc())...)
+	std().logError(logger, LevelError, err, keyval)
 }
 
 // Fatal log error using given logger and exists an application with status code 1.
@@ -96,7 +381,7 @@ func Fatal(logger log.Logger, err error, keyval ...interface{}) {
 	if tl, ok := logger.(*testLogger); ok {
 		tl.t.Helper()
 	}
-	contextErrorFunc(logger, err).Log(append(keyval, KeyLevel, LevelFatal, KeyMessage, err.Error(), KeyTimestamp, timestampFunc())...)
+	std().logError(logger, LevelFatal, err, keyval)
 	os.Exit(1)
 }
 
@@ -105,6 +390,6 @@ func Panic(logger log.Logger, err error, keyval ...interface{}) {
 	if tl, ok := logger.(*testLogger); ok {
 		tl.t.Helper()
 	}
-	contextErrorFunc(logger, err).Log(append(keyval, KeyLevel, LevelPanic, KeyMessage, err.Error(), KeyTimestamp, timestampFunc())...)
+	std().logError(logger, LevelPanic, err, keyval)
 	panic(fmt.Sprint(append(keyval, KeyLevel, LevelPanic, KeyMessage, err)...))
 }
@@ -0,0 +1,125 @@
+package sklog
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/log"
+)
+
+type loggerContextKey struct{}
+
+type keyvalsContextKey struct{}
+
+// ContextWithLogger returns copy of given context that carries given logger.
+func ContextWithLogger(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// ContextWithKeyvals returns copy of given context that carries given key/value pairs,
+// in addition to those already stored in the context.
+func ContextWithKeyvals(ctx context.Context, keyvals ...interface{}) context.Context {
+	if len(keyvals) == 0 {
+		return ctx
+	}
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, log.ErrMissingValue)
+	}
+
+	kv := KeyvalsFromContext(ctx)
+	return context.WithValue(ctx, keyvalsContextKey{}, append(kv[:len(kv):len(kv)], keyvals...))
+}
+
+// KeyvalsFromContext returns key/value pairs stored in given context.
+func KeyvalsFromContext(ctx context.Context) []interface{} {
+	kv, _ := ctx.Value(keyvalsContextKey{}).([]interface{})
+	return kv
+}
+
+// LoggerFromContext returns logger stored in given context as is, without key/value pairs stored in the context.
+// The second return value reports whether the context carries a logger at all.
+func LoggerFromContext(ctx context.Context) (*Logger, bool) {
+	l, ok := ctx.Value(loggerContextKey{}).(*Logger)
+	return l, ok
+}
+
+// FromContext returns logger stored in given context, extended by key/value pairs stored in the context.
+// If there is no logger, the default one, that writes to standard error, is returned.
+func FromContext(ctx context.Context) *Logger {
+	l, ok := LoggerFromContext(ctx)
+	if !ok {
+		l = std()
+	}
+	if kv := KeyvalsFromContext(ctx); len(kv) > 0 {
+		return l.With(kv...)
+	}
+
+	return l
+}
+
+// DebugCtx works like Debug, but adds key/value pairs stored in given context.
+func (l *Logger) DebugCtx(ctx context.Context, msg string, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.With(KeyvalsFromContext(ctx)...).Debug(msg, keyval...)
+}
+
+// InfoCtx works like Info, but adds key/value pairs stored in given context.
+func (l *Logger) InfoCtx(ctx context.Context, msg string, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.With(KeyvalsFromContext(ctx)...).Info(msg, keyval...)
+}
+
+// WarningCtx works like Warning, but adds key/value pairs stored in given context.
+func (l *Logger) WarningCtx(ctx context.Context, msg string, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.With(KeyvalsFromContext(ctx)...).Warning(msg, keyval...)
+}
+
+// ErrorCtx works like Error, but adds key/value pairs stored in given context.
+func (l *Logger) ErrorCtx(ctx context.Context, err error, keyval ...interface{}) {
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.With(KeyvalsFromContext(ctx)...).Error(err, keyval...)
+}
+
+// DebugCtx log message with level debug using logger stored in given context.
+func DebugCtx(ctx context.Context, msg string, keyval ...interface{}) {
+	l := FromContext(ctx)
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.Debug(msg, keyval...)
+}
+
+// InfoCtx log message with level info using logger stored in given context.
+func InfoCtx(ctx context.Context, msg string, keyval ...interface{}) {
+	l := FromContext(ctx)
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.Info(msg, keyval...)
+}
+
+// WarningCtx log message with level warning using logger stored in given context.
+func WarningCtx(ctx context.Context, msg string, keyval ...interface{}) {
+	l := FromContext(ctx)
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.Warning(msg, keyval...)
+}
+
+// ErrorCtx log error with level error using logger stored in given context.
+func ErrorCtx(ctx context.Context, err error, keyval ...interface{}) {
+	l := FromContext(ctx)
+	if tl, ok := l.logger.(*testLogger); ok {
+		tl.t.Helper()
+	}
+	l.Error(err, keyval...)
+}
@@ -0,0 +1,65 @@
+package sklog_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLogger(log.NewJSONLogger(b), sklog.WithKeyvals("app", "example"))
+
+	ctx := sklog.ContextWithLogger(context.Background(), l)
+	ctx = sklog.ContextWithKeyvals(ctx, sklog.KeyRequestID, "abc")
+	ctx = sklog.ContextWithKeyvals(ctx, "user_id", 1)
+
+	sklog.InfoCtx(ctx, "TEST")
+	assert.Contains(t, b.String(), `"level":"info"`)
+	assert.Contains(t, b.String(), `"app":"example"`)
+	assert.Contains(t, b.String(), `"request_id":"abc"`)
+	assert.Contains(t, b.String(), `"user_id":1`)
+	b.Reset()
+
+	sklog.ErrorCtx(ctx, errors.New("sklog_test: example error"))
+	assert.Contains(t, b.String(), `"level":"error"`)
+	assert.Contains(t, b.String(), `"request_id":"abc"`)
+}
+
+func TestFromContext_default(t *testing.T) {
+	l := sklog.FromContext(context.Background())
+	if assert.NotNil(t, l) {
+		assert.Same(t, l, sklog.FromContext(context.Background()))
+	}
+	assert.NotSame(t, l, sklog.FromContext(sklog.ContextWithKeyvals(context.Background(), "user_id", 1)))
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	_, ok := sklog.LoggerFromContext(context.Background())
+	assert.False(t, ok)
+
+	l := sklog.NewLogger(log.NewNopLogger())
+	got, ok := sklog.LoggerFromContext(sklog.ContextWithKeyvals(sklog.ContextWithLogger(context.Background(), l), "user_id", 1))
+	if assert.True(t, ok) {
+		assert.Same(t, l, got)
+	}
+}
+
+func TestLogger_InfoCtx(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLogger(log.NewJSONLogger(b))
+	ctx := sklog.ContextWithKeyvals(context.Background(), "trace_id", "xyz")
+
+	l.InfoCtx(ctx, "TEST")
+	assert.Contains(t, b.String(), `"trace_id":"xyz"`)
+	b.Reset()
+
+	l.WarningCtx(ctx, "TEST")
+	assert.Contains(t, b.String(), `"level":"warn"`)
+	assert.Contains(t, b.String(), `"trace_id":"xyz"`)
+}
@@ -32,6 +32,22 @@ func testSetTimestampFunc(t *testing.T, b *bytes.Buffer, l log.Logger) {
 	assert.Contains(t, b.String(), `"timestamp":"`+fn()+`"`)
 }
 
+func TestSetTimestampFunc_concurrent(t *testing.T) {
+	fn := func() string { return "fake-timestamp" }
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			sklog.SetTimestampFunc(fn)
+			sklog.SetCallerMode(sklog.CallerOff)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		sklog.Info(log.NewNopLogger(), "TEST")
+	}
+	<-done
+}
+
 func TestInfo(t *testing.T) {
 	testLevel(t, testInfoWithOnlyMessage, testInfoWithMessageAndTag)
 }
@@ -123,3 +139,97 @@ func testPanicWithMessageAndTag(t *testing.T, b *bytes.Buffer, l log.Logger) {
 	assert.Contains(t, b.String(), `"timestamp":`)
 	assert.Contains(t, b.String(), `"tag1":"value1"`)
 }
+
+func TestLogger(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLogger(
+		log.NewJSONLogger(b),
+		sklog.WithTimestampFunc(func() string { return "logger-timestamp" }),
+		sklog.WithContextErrorFunc(func(logger log.Logger, err error) *log.Context {
+			return log.NewContext(logger).With("context", "custom")
+		}),
+		sklog.WithStackTraceDepth(0),
+		sklog.WithKeyvals("component", "billing"),
+	)
+
+	l.Info("TEST", "tag1", "value1")
+	assert.Contains(t, b.String(), `"level":"info"`)
+	assert.Contains(t, b.String(), `"msg":"TEST"`)
+	assert.Contains(t, b.String(), `"timestamp":"logger-timestamp"`)
+	assert.Contains(t, b.String(), `"component":"billing"`)
+	assert.Contains(t, b.String(), `"tag1":"value1"`)
+	b.Reset()
+
+	l.Debug("TEST")
+	assert.Contains(t, b.String(), `"level":"debug"`)
+	b.Reset()
+
+	l.Warning("TEST")
+	assert.Contains(t, b.String(), `"level":"warn"`)
+	b.Reset()
+
+	err := errors.New("sklog_test: example error")
+	l.Error(err)
+	assert.Contains(t, b.String(), `"level":"error"`)
+	assert.Contains(t, b.String(), `"msg":"`+err.Error()+`"`)
+	assert.Contains(t, b.String(), `"context":"custom"`)
+	assert.NotContains(t, b.String(), sklog.KeyStackTrace)
+	b.Reset()
+
+	assert.Panics(t, func() {
+		l.Panic(err)
+	})
+	assert.Contains(t, b.String(), `"level":"panic"`)
+	b.Reset()
+
+	// package level shorthands are not affected by Logger configuration.
+	sklog.Info(log.NewJSONLogger(b), "TEST")
+	assert.NotContains(t, b.String(), "logger-timestamp")
+	assert.NotContains(t, b.String(), "component")
+}
+
+func TestLogger_With(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	parent := sklog.NewLogger(log.NewJSONLogger(b), sklog.WithKeyvals("app", "example"))
+	first := parent.With("request_id", "1")
+	second := parent.With("request_id", "2", "odd")
+
+	first.Info("TEST")
+	assert.Contains(t, b.String(), `"app":"example"`)
+	assert.Contains(t, b.String(), `"request_id":"1"`)
+	b.Reset()
+
+	second.Info("TEST")
+	assert.Contains(t, b.String(), `"request_id":"2"`)
+	assert.Contains(t, b.String(), `"odd":"(MISSING)"`)
+	b.Reset()
+
+	parent.Info("TEST")
+	assert.NotContains(t, b.String(), "request_id")
+}
+
+func TestWithKeyvals_odd(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLogger(log.NewJSONLogger(b), sklog.WithKeyvals("odd")).With("request_id", "1")
+
+	l.Info("TEST")
+	assert.Contains(t, b.String(), `"odd":"(MISSING)"`)
+	assert.Contains(t, b.String(), `"request_id":"1"`)
+}
+
+func TestLogger_WithSubsystem(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	parent := sklog.NewLogger(log.NewJSONLogger(b)).WithSubsystem("billing")
+
+	parent.Info("TEST")
+	assert.Contains(t, b.String(), `"subsystem":"billing"`)
+	b.Reset()
+
+	parent.WithSubsystem("invoices").With("id", 1).Info("TEST")
+	assert.Contains(t, b.String(), `"subsystem":"billing.invoices"`)
+	assert.Contains(t, b.String(), `"id":1`)
+	b.Reset()
+
+	parent.Info("TEST", sklog.KeySubsystem, "override")
+	assert.Contains(t, b.String(), `"subsystem":"override"`)
+}
@@ -0,0 +1,138 @@
+package sklog
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+type slogHandler struct {
+	logger  *Logger
+	level   slog.Leveler
+	prefix  string
+	keyvals []interface{}
+}
+
+// NewSlogHandler returns slog.Handler that writes records through given Logger.
+// Levels are mapped to Level* constants, attributes of type error are passed through Logger's context error function.
+// Timestamp is taken from the record (formatted as time.RFC3339), Logger's timestamp function is not used.
+// Records below given level are dropped, nil means slog.LevelInfo.
+func NewSlogHandler(logger *Logger, level slog.Leveler) slog.Handler {
+	if level == nil {
+		level = slog.LevelInfo
+	}
+
+	return &slogHandler{
+		logger: logger,
+		level:  level,
+	}
+}
+
+// Enabled implements slog.Handler interface.
+func (sh *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= sh.level.Level()
+}
+
+// Handle implements slog.Handler interface.
+func (sh *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	kv := make([]interface{}, 0, len(sh.keyvals)+2*r.NumAttrs())
+	kv = append(kv, KeyvalsFromContext(ctx)...)
+	kv = append(kv, sh.keyvals...)
+	r.Attrs(func(attr slog.Attr) bool {
+		kv = sh.appendAttr(kv, sh.prefix, attr)
+		return true
+	})
+
+	// Time and call site are taken from the record, zero time means that it should be omitted
+	// and stack walking would point into log/slog.
+	kv = sh.logger.recordBase(kv, KeyLevel, SlogLevel(r.Level), KeyMessage, r.Message)
+	if !r.Time.IsZero() {
+		kv = append(kv, KeyTimestamp, r.Time.Format(time.RFC3339))
+	}
+	if sh.logger.callerMode != CallerOff && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		kv = append(kv, KeyCaller, filepath.Base(frame.File)+":"+strconv.Itoa(frame.Line))
+		if sh.logger.callerMode == CallerFunc {
+			kv = append(kv, KeyFunction, shortFunction(frame.Function))
+		}
+	}
+
+	return sh.logger.logger.Log(kv...)
+}
+
+// WithAttrs implements slog.Handler interface.
+func (sh *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return sh
+	}
+
+	child := *sh
+	child.keyvals = sh.keyvals[:len(sh.keyvals):len(sh.keyvals)]
+	for _, attr := range attrs {
+		child.keyvals = sh.appendAttr(child.keyvals, sh.prefix, attr)
+	}
+
+	return &child
+}
+
+// WithGroup implements slog.Handler interface.
+// Keys of attributes within a group are prefixed with group name and a dot.
+func (sh *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return sh
+	}
+
+	child := *sh
+	child.prefix = sh.prefix + name + "."
+
+	return &child
+}
+
+func (sh *slogHandler) appendAttr(kv []interface{}, prefix string, attr slog.Attr) []interface{} {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return kv
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, a := range attr.Value.Group() {
+			kv = sh.appendAttr(kv, prefix, a)
+		}
+		return kv
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			kv = append(kv, prefix+attr.Key, err.Error())
+			ctx := contextErrorKeyvals(sh.logger.contextErrorFunc, err)
+			for i := 0; i+1 < len(ctx); i += 2 {
+				if ctx[i] == KeyMessage {
+					continue
+				}
+				kv = append(kv, prefix+keyString(ctx[i]), ctx[i+1])
+			}
+			return kv
+		}
+	}
+
+	return append(kv, prefix+attr.Key, attr.Value.Any())
+}
+
+// SlogLevel maps slog level to one of Level* constants.
+func SlogLevel(level slog.Level) string {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarning
+	default:
+		return LevelError
+	}
+}
@@ -0,0 +1,93 @@
+package sklog_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogLevel(t *testing.T) {
+	success := map[slog.Level]string{
+		slog.LevelDebug - 4: sklog.LevelDebug,
+		slog.LevelDebug:     sklog.LevelDebug,
+		slog.LevelInfo:      sklog.LevelInfo,
+		slog.LevelInfo + 1:  sklog.LevelInfo,
+		slog.LevelWarn:      sklog.LevelWarning,
+		slog.LevelError:     sklog.LevelError,
+		slog.LevelError + 4: sklog.LevelError,
+	}
+
+	for level, expected := range success {
+		assert.Equal(t, expected, sklog.SlogLevel(level), level.String())
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLogger(
+		log.NewJSONLogger(b),
+		sklog.WithCallerMode(sklog.CallerFile),
+	)
+	s := slog.New(sklog.NewSlogHandler(l, slog.LevelInfo))
+
+	s.Debug("debug message")
+	assert.Empty(t, b.String())
+
+	s.Info("info message", "key", "value")
+	exp := previousLine()
+	got := decode(t, b)
+	assert.Equal(t, sklog.LevelInfo, got[sklog.KeyLevel])
+	assert.Equal(t, "info message", got[sklog.KeyMessage])
+	assert.NotEmpty(t, got[sklog.KeyTimestamp])
+	assert.Equal(t, "value", got["key"])
+	assert.Equal(t, exp, got[sklog.KeyCaller])
+
+	s.With("app", "example").WithGroup("http").Warn("warn message", "status", 404, slog.Group("req", "id", 1))
+	got = decode(t, b)
+	assert.Equal(t, sklog.LevelWarning, got[sklog.KeyLevel])
+	assert.Equal(t, "example", got["app"])
+	assert.Equal(t, float64(404), got["http.status"])
+	assert.Equal(t, float64(1), got["http.req.id"])
+}
+
+func TestSlogHandler_time(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	h := sklog.NewSlogHandler(sklog.NewLogger(
+		log.NewJSONLogger(b),
+		sklog.WithTimestampFunc(func() string { return "fake-timestamp" }),
+	), nil)
+
+	at := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	if assert.NoError(t, h.Handle(context.Background(), slog.NewRecord(at, slog.LevelInfo, "replayed", 0))) {
+		assert.Equal(t, "2020-01-02T03:04:05Z", decode(t, b)[sklog.KeyTimestamp])
+	}
+
+	if assert.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "timeless", 0))) {
+		assert.NotContains(t, decode(t, b), sklog.KeyTimestamp)
+	}
+}
+
+func TestSlogHandler_error(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewLogger(log.NewJSONLogger(b), sklog.WithContextErrorFunc(func(logger log.Logger, err error) *log.Context {
+		return sklog.NewContextErrorGeneric(logger, err).With("error_type", fmt.Sprintf("%T", err))
+	}))
+	s := slog.New(sklog.NewSlogHandler(l, nil))
+
+	ctx := sklog.ContextWithKeyvals(context.Background(), sklog.KeyRequestID, "abc")
+	s.ErrorContext(ctx, "request failed", "err", errors.New("sklog_test: example error"))
+	got := decode(t, b)
+	assert.Equal(t, sklog.LevelError, got[sklog.KeyLevel])
+	assert.Equal(t, "request failed", got[sklog.KeyMessage])
+	assert.Equal(t, "sklog_test: example error", got["err"])
+	assert.Equal(t, "*errors.errorString", got["error_type"])
+	assert.Equal(t, "abc", got[sklog.KeyRequestID])
+}
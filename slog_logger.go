@@ -0,0 +1,108 @@
+package sklog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+type slogLogger struct {
+	handler slog.Handler
+}
+
+// NewSlogLogger returns Logger that converts key/value pairs into slog records and passes them to given handler.
+// KeyLevel becomes record level, KeyMessage record message and KeyTimestamp (if in RFC3339 format) record time.
+// If any of them occurs more than once, last one wins. The rest is passed as attributes.
+func NewSlogLogger(handler slog.Handler) log.Logger {
+	return &slogLogger{handler: handler}
+}
+
+// Log implements Logger interface.
+func (sl *slogLogger) Log(keyvals ...interface{}) error {
+	var (
+		level = slog.LevelInfo
+		msg   string
+		ts    time.Time
+		attrs = make([]slog.Attr, 0, len(keyvals)/2)
+	)
+
+	for i := 0; i < len(keyvals); i += 2 {
+		k := keyString(keyvals[i])
+		var v interface{} = log.ErrMissingValue
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+
+		switch k {
+		case KeyLevel:
+			if l, ok := slogLevel(v); ok {
+				level = l
+				continue
+			}
+		case KeyMessage:
+			msg = fmt.Sprint(v)
+			continue
+		case KeyTimestamp:
+			if t, ok := parseTimestamp(v); ok {
+				ts = t
+				continue
+			}
+		}
+		attrs = append(attrs, slog.Any(k, v))
+	}
+
+	ctx := context.Background()
+	if !sl.handler.Enabled(ctx, level) {
+		return nil
+	}
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	var pc uintptr
+	if frame, ok := callerFrame(0); ok {
+		// slog expects a return address, like the ones returned by runtime.Callers,
+		// while frame.PC already points at the call instruction.
+		pc = frame.PC + 1
+	}
+
+	r := slog.NewRecord(ts, level, msg, pc)
+	r.AddAttrs(attrs...)
+
+	return sl.handler.Handle(ctx, r)
+}
+
+// slogLevel maps one of Level* constants to slog level.
+func slogLevel(v interface{}) (slog.Level, bool) {
+	switch v {
+	case LevelDebug:
+		return slog.LevelDebug, true
+	case LevelInfo:
+		return slog.LevelInfo, true
+	case LevelWarning:
+		return slog.LevelWarn, true
+	case LevelError:
+		return slog.LevelError, true
+	case LevelPanic:
+		return slog.LevelError + 4, true
+	case LevelFatal:
+		return slog.LevelError + 8, true
+	default:
+		return 0, false
+	}
+}
+
+func parseTimestamp(v interface{}) (time.Time, bool) {
+	switch x := v.(type) {
+	case time.Time:
+		return x, true
+	case string:
+		t, err := time.Parse(time.RFC3339, x)
+		return t, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,46 @@
+package sklog_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogLogger_Log(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewSlogLogger(slog.NewJSONHandler(b, &slog.HandlerOptions{
+		Level:     slog.LevelInfo,
+		AddSource: true,
+	}))
+
+	sklog.Debug(l, "debug message")
+	assert.Empty(t, b.String())
+
+	sklog.NewLogger(l, sklog.WithTimestampFunc(func() string { return "2017-01-02T15:04:05Z" })).Info("info message", "key", "value")
+	exp := previousLine()
+	assert.Equal(t, 1, strings.Count(b.String(), `"msg"`))
+	assert.Equal(t, 1, strings.Count(b.String(), `"level"`))
+	assert.NotContains(t, b.String(), sklog.KeyTimestamp)
+	got := decode(t, b)
+	assert.Equal(t, "INFO", got[slog.LevelKey])
+	assert.Equal(t, "info message", got[slog.MessageKey])
+	assert.Equal(t, "2017-01-02T15:04:05Z", got[slog.TimeKey])
+	assert.Equal(t, "value", got["key"])
+	if source, ok := got[slog.SourceKey].(map[string]interface{}); assert.True(t, ok) {
+		assert.Equal(t, exp, filepath.Base(source["file"].(string))+":"+strconv.Itoa(int(source["line"].(float64))))
+		assert.True(t, strings.HasSuffix(source["function"].(string), "sklog_test.TestSlogLogger_Log"), source["function"])
+	}
+
+	sklog.Error(l, errors.New("sklog_test: example error"))
+	assert.Equal(t, 1, strings.Count(b.String(), `"msg"`))
+	got = decode(t, b)
+	assert.Equal(t, "ERROR", got[slog.LevelKey])
+	assert.Equal(t, "sklog_test: example error", got[slog.MessageKey])
+}
@@ -0,0 +1,167 @@
+package sklog
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultStackTraceDepth is the number of frames captured for recovered panics,
+// if stack trace capturing was not enabled using SetStackTraceDepth or WithStackTraceDepth.
+const DefaultStackTraceDepth = 32
+
+// StackTrace is a list of stack frames, each formatted as "function file:line".
+type StackTrace []string
+
+var (
+	stackTraceFuncsMu sync.RWMutex
+	stackTraceFuncs   []func(error, int) (StackTrace, bool)
+)
+
+// RegisterStackTrace makes NewStackTrace recognise errors that implement StackTrace() S method,
+// where S is a slice of frames that support %+v verb, printing function and file:line.
+// It allows to reuse stack traces of third party packages, e.g. github.com/pkg/errors:
+//
+//	sklog.RegisterStackTrace[errors.StackTrace]()
+func RegisterStackTrace[S ~[]F, F fmt.Formatter]() {
+	stackTraceFuncsMu.Lock()
+	defer stackTraceFuncsMu.Unlock()
+
+	stackTraceFuncs = append(stackTraceFuncs, func(err error, depth int) (StackTrace, bool) {
+		x, ok := err.(interface{ StackTrace() S })
+		if !ok {
+			return nil, false
+		}
+		return formatterStackTrace(x.StackTrace(), depth), true
+	})
+}
+
+// SetStackTraceDepth sets maximum number of frames captured by Error, Fatal and Panic.
+// Zero, the default, disables stack trace capturing.
+func SetStackTraceDepth(depth int) {
+	setStd(WithStackTraceDepth(depth))
+}
+
+func appendStackTrace(keyvals []interface{}, err error, depth int) []interface{} {
+	if depth <= 0 {
+		return keyvals
+	}
+	if st := NewStackTrace(err, depth); len(st) > 0 {
+		return append(keyvals, KeyStackTrace, st)
+	}
+
+	return keyvals
+}
+
+// NewStackTrace returns stack trace attached to given error or any error it wraps,
+// if there is none it captures stack trace of the current goroutine.
+// Errors can provide stack trace by implementing StackTrace() StackTrace
+// or Callers() []uintptr method, the latter returning program counters like runtime.Callers does.
+// Other stack trace types can be supported using RegisterStackTrace.
+// Frames that belong to runtime and sklog are omitted.
+func NewStackTrace(err error, depth int) StackTrace {
+	if st, ok := errorStackTrace(err, depth); ok {
+		return st
+	}
+
+	pcs := make([]uintptr, depth+maxCallerFrames)
+	n := runtime.Callers(2, pcs)
+
+	return framesStackTrace(pcs[:n], depth)
+}
+
+func errorStackTrace(err error, depth int) (StackTrace, bool) {
+	stackTraceFuncsMu.RLock()
+	funcs := stackTraceFuncs
+	stackTraceFuncsMu.RUnlock()
+
+	for _, e := range errorTree(err) {
+		if isNilPointer(e) {
+			continue
+		}
+		switch x := e.(type) {
+		case interface{ StackTrace() StackTrace }:
+			return x.StackTrace(), true
+		case interface{ Callers() []uintptr }:
+			return framesStackTrace(x.Callers(), depth), true
+		}
+		for _, fn := range funcs {
+			if st, ok := fn(e, depth); ok {
+				return st, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// formatterStackTrace converts frames that support %+v verb, like github.com/pkg/errors.Frame, into StackTrace.
+func formatterStackTrace[F fmt.Formatter](frames []F, depth int) StackTrace {
+	var st StackTrace
+	for _, frame := range frames {
+		// "function\n\tfile:line" becomes "function file:line".
+		fields := strings.Fields(fmt.Sprintf("%+v", frame))
+		if len(fields) == 0 || isOmittedFrame(fields[0]) {
+			continue
+		}
+		fields[0] = shortFunction(fields[0])
+		st = append(st, strings.Join(fields, " "))
+		if len(st) == depth {
+			break
+		}
+	}
+
+	return st
+}
+
+func framesStackTrace(pcs []uintptr, depth int) StackTrace {
+	var st StackTrace
+
+	frames := runtime.CallersFrames(pcs)
+	for len(st) < depth {
+		frame, more := frames.Next()
+		if !isOmittedFrame(frame.Function) {
+			st = append(st, shortFunction(frame.Function)+" "+frame.File+":"+strconv.Itoa(frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+
+	return st
+}
+
+func isOmittedFrame(function string) bool {
+	return strings.HasPrefix(function, "runtime.") || isSklogFrame(function)
+}
+
+// errorTree returns given error and all errors it wraps in depth-first order.
+func errorTree(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	res := []error{err}
+	if isNilPointer(err) {
+		return res
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		res = append(res, errorTree(x.Unwrap())...)
+	case interface{ Unwrap() []error }:
+		for _, e := range x.Unwrap() {
+			res = append(res, errorTree(e)...)
+		}
+	}
+
+	return res
+}
+
+// isNilPointer reports whether given error is a nil pointer, calling its methods could panic.
+func isNilPointer(err error) bool {
+	v := reflect.ValueOf(err)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
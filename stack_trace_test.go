@@ -0,0 +1,110 @@
+package sklog_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+type stackError struct {
+	stack sklog.StackTrace
+}
+
+func (se *stackError) Error() string                { return "sklog_test: stack error" }
+func (se *stackError) StackTrace() sklog.StackTrace { return se.stack }
+
+// frame mimics github.com/pkg/errors.Frame.
+type frame string
+
+func (f frame) Format(s fmt.State, verb rune) {
+	fmt.Fprintf(s, "%s\n\t/src/example.go:1", string(f))
+}
+
+// frames mimics github.com/pkg/errors.StackTrace.
+type frames []frame
+
+type frameError struct{}
+
+func (fe *frameError) Error() string { return "sklog_test: frame error" }
+func (fe *frameError) StackTrace() frames {
+	return frames{"github.com/example/pkg.Do", "runtime.main", "github.com/example/pkg.Run"}
+}
+
+type callersError struct {
+	pcs []uintptr
+}
+
+func (ce *callersError) Error() string      { return "sklog_test: callers error" }
+func (ce *callersError) Callers() []uintptr { return ce.pcs }
+
+func newCallersError() error {
+	pcs := make([]uintptr, 8)
+	return &callersError{pcs: pcs[:runtime.Callers(1, pcs)]}
+}
+
+func stackTrace(t *testing.T, b *bytes.Buffer) []interface{} {
+	st, _ := decode(t, b)[sklog.KeyStackTrace].([]interface{})
+	return st
+}
+
+func TestError_stackTrace(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := log.NewJSONLogger(b)
+
+	sklog.Error(l, errors.New("sklog_test: example error"))
+	assert.NotContains(t, b.String(), sklog.KeyStackTrace)
+	b.Reset()
+
+	sklog.SetStackTraceDepth(sklog.DefaultStackTraceDepth)
+	defer sklog.SetStackTraceDepth(0)
+
+	sklog.Error(l, errors.New("sklog_test: example error"))
+	st := stackTrace(t, b)
+	if assert.NotEmpty(t, st) {
+		assert.Contains(t, st[0], "sklog_test.TestError_stackTrace ")
+		assert.Contains(t, st[0], "stack_trace_test.go:")
+	}
+	for _, f := range st {
+		assert.False(t, strings.HasPrefix(f.(string), "runtime."), f)
+		assert.False(t, strings.HasPrefix(f.(string), "sklog."), f)
+	}
+
+	sklog.Error(l, fmt.Errorf("wrapped: %w", &stackError{stack: sklog.StackTrace{"main.main /src/main.go:1"}}))
+	assert.Equal(t, []interface{}{"main.main /src/main.go:1"}, stackTrace(t, b))
+
+	sklog.RegisterStackTrace[frames]()
+	sklog.Error(l, errors.Join(errors.New("first"), &frameError{}))
+	assert.Equal(t, []interface{}{"pkg.Do /src/example.go:1", "pkg.Run /src/example.go:1"}, stackTrace(t, b))
+
+	sklog.Error(l, errors.Join(errors.New("first"), newCallersError()))
+	st = stackTrace(t, b)
+	if assert.NotEmpty(t, st) {
+		assert.Contains(t, st[0], "sklog_test.newCallersError ")
+	}
+
+	var nilErr *stackError
+	assert.NotPanics(t, func() {
+		sklog.Error(l, fmt.Errorf("wrapped: %w", nilErr))
+	})
+}
+
+func TestSetStackTraceDepth(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	l := log.NewJSONLogger(b)
+
+	sklog.SetStackTraceDepth(1)
+	sklog.Error(l, errors.New("sklog_test: example error"))
+	assert.Len(t, stackTrace(t, b), 1)
+
+	sklog.SetStackTraceDepth(0)
+
+	sklog.Error(l, errors.New("sklog_test: example error"))
+	assert.NotContains(t, b.String(), sklog.KeyStackTrace)
+}
@@ -0,0 +1,239 @@
+package sklog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+)
+
+// placeholderRest marks place where remaining key/value pairs are written.
+const placeholderRest = "\x00rest\x00"
+
+var (
+	ansiCodes = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+	templateColors = map[string]string{
+		"bold":   colorBold,
+		"dim":    colorDim,
+		"red":    colorRed,
+		"yellow": colorYellow,
+		"blue":   colorBlue,
+		"gray":   colorGray,
+	}
+
+	templateFuncs = template.FuncMap{
+		"level":   templateLevel,
+		"pad":     templatePad,
+		"trunc":   templateTrunc,
+		"color":   templateColor,
+		"time":    templateTime,
+		"default": templateDefault,
+		"rest":    func() string { return placeholderRest },
+	}
+)
+
+type templateFormatter struct {
+	tmpl *template.Template
+	keys map[string]struct{}
+}
+
+// NewTemplateFormatter allocates Formatter that renders records using given text/template layout, for example:
+//
+//	{{.timestamp}} {{level .level}} {{if .subsystem}}[{{.subsystem}}] {{end}}{{pad 40 .msg}} {{rest}}
+//
+// Record keys are accessible as fields, missing ones are rendered as empty strings. Available functions:
+//
+//	level v            level padded to 5 characters and colored
+//	pad n v            v padded with spaces to n characters (right aligned if n is negative)
+//	trunc n v          v truncated to n characters, "…" marks truncation
+//	color name v       v in given color: bold, dim, red, yellow, blue or gray
+//	time layout v      RFC3339 timestamp reformatted using given layout
+//	default def v      def if v is missing or empty
+//	rest               key/value pairs that are not referenced by the layout
+//
+// Colors are stripped if the output is not colored (see WithColor).
+// The layout is parsed once, formatter can be used concurrently.
+func NewTemplateFormatter(layout string) (Formatter, error) {
+	tmpl, err := template.New("sklog").Funcs(templateFuncs).Parse(layout)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]struct{})
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			templateKeys(t.Tree.Root, keys)
+		}
+	}
+
+	return &templateFormatter{tmpl: tmpl, keys: keys}, nil
+}
+
+// Format implements Formatter interface.
+func (tf *templateFormatter) Format(w io.Writer, v interface{}) (int, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("sklog: template formatter expects map[string]interface{} got %T", v)
+	}
+
+	b := bytes.NewBuffer(nil)
+	if err := tf.tmpl.Execute(b, tf.data(m)); err != nil {
+		return 0, err
+	}
+
+	out := b.String()
+	if !isColored(w) {
+		out = ansiCodes.ReplaceAllString(out, "")
+	}
+
+	before, after, found := strings.Cut(out, placeholderRest)
+	n, err := io.WriteString(w, before)
+	if err != nil || !found {
+		return n, err
+	}
+
+	rest := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		if _, ok := tf.keys[key]; !ok {
+			rest[key] = value
+		}
+	}
+
+	n1, err := writeKV(w, rest)
+	n += n1
+	if err != nil {
+		return n, err
+	}
+	// Only the first occurrence is replaced by key/value pairs.
+	n1, err = io.WriteString(w, strings.Replace(after, placeholderRest, "", -1))
+
+	return n + n1, err
+}
+
+// data returns record that is passed to the template. Referenced keys that are missing or nil are set to empty strings,
+// otherwise the template would render them as "<no value>". Given map is not modified.
+func (tf *templateFormatter) data(m map[string]interface{}) map[string]interface{} {
+	var data map[string]interface{}
+	for key := range tf.keys {
+		if m[key] != nil {
+			continue
+		}
+		if data == nil {
+			data = make(map[string]interface{}, len(m)+len(tf.keys))
+			for k, v := range m {
+				data[k] = v
+			}
+		}
+		data[key] = ""
+	}
+	if data == nil {
+		return m
+	}
+
+	return data
+}
+
+// templateKeys collects record keys referenced by the template.
+func templateKeys(node parse.Node, keys map[string]struct{}) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			templateKeys(child, keys)
+		}
+	case *parse.ActionNode:
+		templateKeys(n.Pipe, keys)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			templateKeys(cmd, keys)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			templateKeys(arg, keys)
+		}
+	case *parse.FieldNode:
+		keys[n.Ident[0]] = struct{}{}
+	case *parse.VariableNode:
+		// $.key refers to the record regardless of the current dot.
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			keys[n.Ident[1]] = struct{}{}
+		}
+	case *parse.ChainNode:
+		templateKeys(n.Node, keys)
+	case *parse.IfNode:
+		templateKeys(n.Pipe, keys)
+		templateKeys(n.List, keys)
+		templateKeys(n.ElseList, keys)
+	case *parse.RangeNode:
+		templateKeys(n.Pipe, keys)
+		templateKeys(n.List, keys)
+		templateKeys(n.ElseList, keys)
+	case *parse.WithNode:
+		templateKeys(n.Pipe, keys)
+		templateKeys(n.List, keys)
+		templateKeys(n.ElseList, keys)
+	case *parse.TemplateNode:
+		templateKeys(n.Pipe, keys)
+	}
+}
+
+func templateLevel(v interface{}) string {
+	return colorize(levelColor(v), fmt.Sprintf("%-5v", templateString(v)))
+}
+
+func templatePad(n int, v interface{}) string {
+	if n < 0 {
+		return fmt.Sprintf("%*s", -n, templateString(v))
+	}
+
+	return fmt.Sprintf("%-*s", n, templateString(v))
+}
+
+func templateTrunc(n int, v interface{}) string {
+	s := templateString(v)
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	return string([]rune(s)[:n-1]) + "…"
+}
+
+func templateColor(name string, v interface{}) string {
+	return colorize(templateColors[name], templateString(v))
+}
+
+func templateTime(layout string, v interface{}) string {
+	s := templateString(v)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Format(layout)
+	}
+
+	return s
+}
+
+func templateDefault(def, v interface{}) interface{} {
+	if templateString(v) == "" {
+		return def
+	}
+
+	return v
+}
+
+func templateString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,128 @@
+package sklog_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/piotrkowalczuk/sklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTemplateFormatter(t *testing.T) {
+	record := func() map[string]interface{} {
+		return map[string]interface{}{
+			sklog.KeyTimestamp: "2017-03-04T12:30:45Z",
+			sklog.KeyLevel:     sklog.LevelInfo,
+			sklog.KeyMessage:   "request processed",
+			"user":             "john",
+			"attempt":          2,
+		}
+	}
+
+	cases := map[string]struct {
+		layout   string
+		expected string
+	}{
+		"fields": {
+			layout:   `{{.timestamp}} {{.level}} {{.msg}}`,
+			expected: `2017-03-04T12:30:45Z info request processed`,
+		},
+		"level": {
+			layout:   `[{{level .level}}]`,
+			expected: `[info ]`,
+		},
+		"pad": {
+			layout:   `|{{pad 10 .user}}|{{pad -6 .attempt}}|`,
+			expected: `|john      |     2|`,
+		},
+		"trunc": {
+			layout:   `{{trunc 8 .msg}} {{trunc 8 .user}}`,
+			expected: `request… john`,
+		},
+		"color": {
+			layout:   `{{color "red" .msg}}`,
+			expected: `request processed`,
+		},
+		"time": {
+			layout:   `{{time "15:04:05" .timestamp}}`,
+			expected: `12:30:45`,
+		},
+		"default": {
+			layout:   `{{default "-" .subsystem}} {{default "-" .user}}`,
+			expected: `- john`,
+		},
+		"missing": {
+			layout:   `[{{.subsystem}}]`,
+			expected: `[]`,
+		},
+		"conditional": {
+			layout:   `{{if .subsystem}}[{{.subsystem}}] {{end}}{{.msg}}`,
+			expected: `request processed`,
+		},
+		"rest": {
+			layout:   `{{.msg}} {{rest}}|`,
+			expected: `request processed attempt=2  level=info  timestamp=2017-03-04T12:30:45Z  user=john  |`,
+		},
+		"variable": {
+			layout:   `{{with .user}}{{.}}: {{$.msg}}{{end}} {{rest}}|`,
+			expected: `john: request processed attempt=2  level=info  timestamp=2017-03-04T12:30:45Z  |`,
+		},
+		"define": {
+			layout:   `{{define "user"}}<{{.user}}>{{end}}{{template "user" .}} {{rest}}|`,
+			expected: `<john> attempt=2  level=info  msg=request processed  timestamp=2017-03-04T12:30:45Z  |`,
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			f, err := sklog.NewTemplateFormatter(c.layout)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			b := bytes.NewBuffer(nil)
+			if _, err = f.Format(b, record()); assert.NoError(t, err) {
+				assert.Equal(t, c.expected, b.String())
+			}
+		})
+	}
+}
+
+func TestNewTemplateFormatter_noValue(t *testing.T) {
+	f, err := sklog.NewTemplateFormatter(`{{.msg}} [{{.subsystem}}] [{{.user}}]`)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	record := map[string]interface{}{sklog.KeyMessage: "got <no value>", "user": nil}
+	b := bytes.NewBuffer(nil)
+	if _, err = f.Format(b, record); assert.NoError(t, err) {
+		assert.Equal(t, "got <no value> [] []", b.String())
+	}
+	assert.Len(t, record, 2)
+}
+
+func TestNewTemplateFormatter_humane(t *testing.T) {
+	f, err := sklog.NewTemplateFormatter(`{{level .level}} {{color "bold" .msg}} {{rest}}`)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b := bytes.NewBuffer(nil)
+	l := sklog.NewHumaneLogger(b, f, sklog.WithColor(true))
+
+	if assert.NoError(t, l.Log(sklog.KeyLevel, sklog.LevelError, sklog.KeyMessage, "failure", "b", 2, "a", 1)) {
+		assert.Equal(t, "\x1b[31merror\x1b[0m \x1b[1mfailure\x1b[0m \x1b[2mb=\x1b[0m\x1b[1m2\x1b[0m  \x1b[2ma=\x1b[0m\x1b[1m1\x1b[0m  \n", b.String())
+	}
+
+	b.Reset()
+	l = sklog.NewHumaneLogger(b, f, sklog.WithColor(false))
+	if assert.NoError(t, l.Log(sklog.KeyLevel, sklog.LevelError, sklog.KeyMessage, "failure", "b", 2, "a", 1)) {
+		assert.Equal(t, "error failure b=2  a=1  \n", b.String())
+	}
+}
+
+func TestNewTemplateFormatter_invalid(t *testing.T) {
+	_, err := sklog.NewTemplateFormatter(`{{.msg`)
+	assert.Error(t, err)
+}
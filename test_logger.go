@@ -9,41 +9,45 @@ import (
 )
 
 type testLogger struct {
+	humaneConfig
 	t *testing.T
 }
 
-// NewMultiLogger returns a Logger that wraps testing object.
-func NewTestLogger(t *testing.T) log.Logger {
+// NewTestLogger returns a Logger that wraps testing object.
+// Options that affect key/value pairs (like WithKeyOrder) work the same way as in humane logger.
+func NewTestLogger(t *testing.T, opts ...HumaneOption) log.Logger {
 	return &testLogger{
-		t: t,
+		humaneConfig: newHumaneConfig(opts),
+		t:            t,
 	}
 }
 
 // Log implements Logger interface.
 func (tl *testLogger) Log(keyvals ...interface{}) error {
 	tl.t.Helper()
-	n := (len(keyvals) + 1) / 2 // +1 to handle case when len is odd
-	m := make(map[string]interface{}, n)
-
-	for i := 0; i < len(keyvals); i += 2 {
-		k := keyvals[i]
-		var v interface{} = log.ErrMissingValue
-		if i+1 < len(keyvals) {
-			v = keyvals[i+1]
+	msg, duplicates := tl.format(keyvals)
+	tl.t.Log(msg)
+	if tl.reservedKeyLog {
+		for _, warning := range reservedKeyWarnings(duplicates) {
+			tl.t.Log(warning)
 		}
-		merge(m, k, v)
 	}
 
+	return nil
+}
+
+func (tl *testLogger) format(keyvals []interface{}) (string, []string) {
+	m, keys, duplicates := merge(keyvals, tl.duplicates)
+
 	buf := bytes.NewBuffer(nil)
 	if msg, ok := m[KeyMessage]; ok {
 		fmt.Fprintf(buf, "%-60s", msg)
 		delete(m, KeyMessage)
 
 	}
-	for k, v := range m {
-		fmt.Fprintf(buf, "%s=%v  ", k, v)
+	for _, k := range sortKeys(keys, m, tl.order) {
+		fmt.Fprintf(buf, "%s=%v  ", k, m[k])
 	}
-	tl.t.Log(buf.String())
 
-	return nil
+	return buf.String(), duplicates
 }